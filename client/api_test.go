@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchScheduleUserAgent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "configured-agent", r.Header.Get("User-Agent"))
+		w.Write([]byte(`{"csvData":"Date,Time\n"}`))
+	}))
+	defer srv.Close()
+
+	orig := Default()
+	defer SetDefault(orig)
+	SetDefault(NewFetcher(FetcherConfig{UserAgent: "configured-agent"}))
+
+	schedule, err := NewAPIClient(srv.URL).FetchSchedule(context.Background(), "instance", "comp")
+	require.NoError(t, err)
+	assert.Equal(t, "Date,Time\n", schedule.CSVData)
+}
+
+func TestFetchScheduleMasksInstance(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close() // every request fails to connect
+
+	orig := Default()
+	defer SetDefault(orig)
+	f := NewFetcher(FetcherConfig{Retries: -1})
+	SetDefault(f)
+
+	_, err := NewAPIClient(srv.URL).FetchSchedule(context.Background(), "secret-token", "comp")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-token")
+	assert.Contains(t, err.Error(), "instance=[MASKED]")
+
+	stats := f.Stats()
+	require.Len(t, stats, 1)
+	assert.NotEmpty(t, stats[0].LastError)
+	assert.NotContains(t, stats[0].LastError, "secret-token")
+}
+
+func TestFetchScheduleDefaultUserAgent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ivpUserAgent, r.Header.Get("User-Agent"))
+		w.Write([]byte(`{"csvData":"Date,Time\n"}`))
+	}))
+	defer srv.Close()
+
+	orig := Default()
+	defer SetDefault(orig)
+	SetDefault(NewFetcher(FetcherConfig{}))
+
+	_, err := NewAPIClient(srv.URL).FetchSchedule(context.Background(), "instance", "comp")
+	require.NoError(t, err)
+}
@@ -0,0 +1,464 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultUserAgent identifies the watcher to the sites it polls unless the
+// config sets http.user_agent or the source's client picks another.
+const DefaultUserAgent = "ScheduleWatcher/1.0"
+
+// secretParamRe matches query parameters that carry credentials, such as
+// the Wix instance token, so they can be masked in errors and stats.
+var secretParamRe = regexp.MustCompile(`(?i)([?&]instance=)[^&\s"]*`)
+
+// FetcherConfig tunes a Fetcher. Zero values pick the defaults.
+type FetcherConfig struct {
+	UserAgent string
+	Timeout   time.Duration // per attempt; default 30s
+	Retries   int           // attempts after the first; negative means none
+	Backoff   time.Duration // wait before the first retry, doubling after; default 1s
+	MaxWait   time.Duration // longest wait between attempts; default 30s
+}
+
+// Fetcher performs the GET requests of every league client. It retries
+// network errors, 429s and 5xx responses with exponential backoff and
+// jitter, and remembers each URL's ETag and Last-Modified so an unchanged
+// page costs a 304 and is served from memory. Requests are counted per host
+// (Stats).
+type Fetcher struct {
+	httpClient     *http.Client
+	userAgent      string
+	userAgentIsSet bool // configured rather than DefaultUserAgent
+	retries        int
+	backoff        time.Duration
+	maxWait        time.Duration
+
+	// sleep waits between attempts, returning early with ctx's error if it
+	// is cancelled; stubbed in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu        sync.Mutex
+	responses map[string]cachedResponse // by URL
+	stats     map[string]*HostStats     // by host
+}
+
+// cachedResponse is the last 200 response for a URL that carried a validator.
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	body         string
+}
+
+// HostStats counts the requests made to one host.
+type HostStats struct {
+	Host        string        `json:"host"`
+	Requests    int           `json:"requests"`      // attempts, including retries
+	Retries     int           `json:"retries"`       // attempts after a failed one
+	Failures    int           `json:"failures"`      // fetches that gave up
+	NotModified int           `json:"not_modified"`  // 304s served from memory
+	Waited      time.Duration `json:"waited_ns"`     // held back by a Limiter
+	Bytes       int64         `json:"bytes"`         // response bodies read
+	TotalTime   time.Duration `json:"total_time_ns"` // spent in attempts
+	LastStatus  int           `json:"last_status"`   // 0 when the last attempt got no response
+	LastError   string        `json:"last_error,omitempty"`
+	LastFetch   time.Time     `json:"last_fetch"`
+}
+
+// StatusError is returned for a response other than 200 or 304.
+type StatusError struct {
+	StatusCode int
+	Body       string // start of the response body, for the log
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status code: %d: %s", e.StatusCode, e.Body)
+}
+
+// NewFetcher returns a Fetcher configured by cfg.
+func NewFetcher(cfg FetcherConfig) *Fetcher {
+	userAgentIsSet := cfg.UserAgent != ""
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = DefaultUserAgent
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.Retries < 0 {
+		cfg.Retries = 0
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = time.Second
+	}
+	if cfg.MaxWait <= 0 {
+		cfg.MaxWait = 30 * time.Second
+	}
+	return &Fetcher{
+		httpClient:     &http.Client{Timeout: cfg.Timeout},
+		userAgent:      cfg.UserAgent,
+		userAgentIsSet: userAgentIsSet,
+		retries:        cfg.Retries,
+		backoff:        cfg.Backoff,
+		maxWait:        cfg.MaxWait,
+		sleep:          sleepContext,
+		responses:      make(map[string]cachedResponse),
+		stats:          make(map[string]*HostStats),
+	}
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultFetcher = NewFetcher(FetcherConfig{Retries: 3})
+)
+
+// Default returns the Fetcher league clients share.
+func Default() *Fetcher {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultFetcher
+}
+
+// SetDefault replaces the shared Fetcher. Call it before building leagues,
+// which keep the Fetcher they were built with.
+func SetDefault(f *Fetcher) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultFetcher = f
+}
+
+// Limiter spaces requests at least an interval apart, for sites that
+// shouldn't be asked for many pages at once. Requests made through the same
+// Limiter queue up behind each other.
+type Limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time // when the latest request was let start
+}
+
+// NewLimiter returns a Limiter allowing one request per interval. A zero
+// interval doesn't limit at all.
+func NewLimiter(interval time.Duration) *Limiter {
+	return &Limiter{interval: interval}
+}
+
+// reserve takes the next free slot and returns how long until it starts.
+func (l *Limiter) reserve() time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.interval <= 0 {
+		return 0
+	}
+	now := time.Now()
+	at := l.last.Add(l.interval)
+	if at.Before(now) {
+		at = now
+	}
+	l.last = at
+	return at.Sub(now)
+}
+
+var (
+	hostLimitersMu sync.Mutex
+	hostLimiters   = make(map[string]*Limiter) // by host
+)
+
+// HostLimiter returns the Limiter shared by every client of host (e.g.
+// "example.com:8080", as in a URL), so several clients of one site together
+// keep to its pace. It spaces requests at least interval apart; of the
+// intervals asked for, the longest applies until ResetHostLimits.
+func HostLimiter(host string, interval time.Duration) *Limiter {
+	hostLimitersMu.Lock()
+	defer hostLimitersMu.Unlock()
+	lim, ok := hostLimiters[host]
+	if !ok {
+		lim = NewLimiter(0)
+		hostLimiters[host] = lim
+	}
+	lim.mu.Lock()
+	if interval > lim.interval {
+		lim.interval = interval
+	}
+	lim.mu.Unlock()
+	return lim
+}
+
+// ResetHostLimits forgets the intervals asked for so far, so the clients
+// built next (after a config reload, say) set them afresh and a shortened
+// interval applies. The limiters are kept, so the next request is still
+// spaced from the last one let through.
+func ResetHostLimits() {
+	hostLimitersMu.Lock()
+	defer hostLimitersMu.Unlock()
+	for _, lim := range hostLimiters {
+		lim.mu.Lock()
+		lim.interval = 0
+		lim.mu.Unlock()
+	}
+}
+
+// UserAgentIsSet reports whether the User-Agent was configured rather than
+// left to DefaultUserAgent, for clients whose site expects another default.
+func (f *Fetcher) UserAgentIsSet() bool { return f.userAgentIsSet }
+
+// Get fetches rawURL with the given request headers and returns the body.
+// User-Agent is set unless headers sets it. Cancelling ctx abandons the
+// request and any retries still to come.
+func (f *Fetcher) Get(ctx context.Context, rawURL string, headers map[string]string) (string, error) {
+	return f.GetLimited(ctx, nil, rawURL, headers)
+}
+
+// GetLimited is Get with each attempt, retries included, waiting its turn
+// on lim. A nil lim doesn't limit.
+func (f *Fetcher) GetLimited(ctx context.Context, lim *Limiter, rawURL string, headers map[string]string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing URL: %w", redactErr(err))
+	}
+	host := u.Host
+
+	for attempt := 0; ; attempt++ {
+		body, retryAfter, err := f.try(ctx, lim, rawURL, host, headers, attempt > 0)
+		if err == nil {
+			return body, nil
+		}
+		if ctx.Err() != nil {
+			return "", err
+		}
+		if attempt >= f.retries || !retryable(err) {
+			f.record(host, func(s *HostStats) { s.Failures++ })
+			return "", err
+		}
+		if err := f.sleep(ctx, f.wait(attempt, retryAfter)); err != nil {
+			return "", err
+		}
+	}
+}
+
+// try makes one attempt. retryAfter is the wait the server asked for, if any.
+func (f *Fetcher) try(ctx context.Context, lim *Limiter, rawURL, host string, headers map[string]string, retry bool) (string, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return "", 0, fmt.Errorf("creating request: %w", redactErr(err))
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	f.mu.Lock()
+	cached, haveCached := f.responses[rawURL]
+	f.mu.Unlock()
+	if haveCached {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	if err := f.waitTurn(ctx, lim, host); err != nil {
+		return "", 0, err
+	}
+	start := time.Now()
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		err = redactErr(err)
+		f.record(host, func(s *HostStats) {
+			s.count(retry, start)
+			s.LastStatus = 0
+			s.LastError = err.Error()
+		})
+		return "", 0, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, readErr := readBody(resp)
+	f.record(host, func(s *HostStats) {
+		s.count(retry, start)
+		s.LastStatus = resp.StatusCode
+		s.Bytes += int64(len(body))
+		s.LastError = ""
+		if readErr != nil {
+			s.LastError = readErr.Error()
+		}
+		if resp.StatusCode == http.StatusNotModified && haveCached {
+			s.NotModified++
+		}
+	})
+	if readErr != nil {
+		return "", 0, fmt.Errorf("reading response body: %w", readErr)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && haveCached:
+		return cached.body, 0, nil
+	case resp.StatusCode != http.StatusOK:
+		if len(body) > 200 {
+			body = body[:200]
+		}
+		return "", retryAfter(resp), &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(body)}
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	f.mu.Lock()
+	if etag != "" || lastModified != "" {
+		f.responses[rawURL] = cachedResponse{etag: etag, lastModified: lastModified, body: body}
+	} else {
+		delete(f.responses, rawURL)
+	}
+	f.mu.Unlock()
+	return body, 0, nil
+}
+
+// waitTurn blocks until lim allows another request, counting the wait
+// against host.
+func (f *Fetcher) waitTurn(ctx context.Context, lim *Limiter, host string) error {
+	wait := lim.reserve()
+	if wait <= 0 {
+		return nil
+	}
+	f.record(host, func(s *HostStats) { s.Waited += wait })
+	return f.sleep(ctx, wait)
+}
+
+// sleepContext waits for d, or until ctx is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// readBody reads the response, decompressing it when the caller asked for
+// gzip itself (the transport only decompresses when it asked).
+func readBody(resp *http.Response) (string, error) {
+	var reader io.Reader = resp.Body
+	if strings.Contains(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return "", fmt.Errorf("creating gzip reader: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	data, err := io.ReadAll(reader)
+	return string(data), err
+}
+
+// retryable reports whether a failed attempt is worth repeating: no
+// response at all, rate limiting, or a server error.
+func retryable(err error) bool {
+	statusErr, ok := err.(*StatusError)
+	if !ok {
+		return true
+	}
+	return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+}
+
+// retryAfter returns the wait a Retry-After header asks for, in seconds or
+// as a date, or 0.
+func retryAfter(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// wait returns how long to sleep before the retry following attempt: the
+// backoff doubled per attempt with up to half of it taken off at random, so
+// leagues on the same host don't retry in step, or what the server asked
+// for if that's longer. Never more than maxWait.
+func (f *Fetcher) wait(attempt int, asked time.Duration) time.Duration {
+	d := f.backoff << attempt
+	if d <= 0 || d > f.maxWait {
+		d = f.maxWait
+	}
+	d -= time.Duration(rand.Int63n(int64(d)/2 + 1))
+	if asked > d {
+		d = asked
+	}
+	if d > f.maxWait {
+		d = f.maxWait
+	}
+	return d
+}
+
+// record updates host's stats under the lock.
+func (f *Fetcher) record(host string, update func(s *HostStats)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	update(f.statsFor(host))
+}
+
+// statsFor returns host's stats, creating them. f.mu must be held.
+func (f *Fetcher) statsFor(host string) *HostStats {
+	s, ok := f.stats[host]
+	if !ok {
+		s = &HostStats{Host: host}
+		f.stats[host] = s
+	}
+	return s
+}
+
+// count records one attempt that started at start.
+func (s *HostStats) count(retry bool, start time.Time) {
+	s.Requests++
+	if retry {
+		s.Retries++
+	}
+	s.TotalTime += time.Since(start)
+	s.LastFetch = start
+}
+
+// Stats returns the request counts for each host fetched from, by host name.
+func (f *Fetcher) Stats() []HostStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stats := make([]HostStats, 0, len(f.stats))
+	for _, s := range f.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Host < stats[j].Host })
+	return stats
+}
+
+// redactErr masks the credentials in the URL of a *url.Error in err's
+// chain, which the error's message would otherwise include, and returns err.
+func redactErr(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = secretParamRe.ReplaceAllString(urlErr.URL, "${1}[MASKED]")
+	}
+	return err
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFetcher(retries int) (*Fetcher, *[]time.Duration) {
+	f := NewFetcher(FetcherConfig{UserAgent: "test-agent", Retries: retries})
+	var waits []time.Duration
+	f.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return f, &waits
+}
+
+func TestFetcherRetriesServerErrors(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "test-agent", r.Header.Get("User-Agent"))
+		assert.Equal(t, "text/csv", r.Header.Get("Accept"))
+		if calls < 3 {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	f, waits := testFetcher(3)
+	body, err := f.Get(context.Background(), srv.URL, map[string]string{"Accept": "text/csv"})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", body)
+	assert.Equal(t, 3, calls)
+
+	require.Len(t, *waits, 2)
+	assert.True(t, (*waits)[0] >= 500*time.Millisecond && (*waits)[0] <= time.Second, "first wait %s", (*waits)[0])
+	assert.True(t, (*waits)[1] >= time.Second && (*waits)[1] <= 2*time.Second, "second wait %s", (*waits)[1])
+
+	stats := f.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, 3, stats[0].Requests)
+	assert.Equal(t, 2, stats[0].Retries)
+	assert.Equal(t, 0, stats[0].Failures)
+	assert.Equal(t, http.StatusOK, stats[0].LastStatus)
+}
+
+func TestFetcherGivesUp(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	f, waits := testFetcher(2)
+	_, err := f.Get(context.Background(), srv.URL+"/busy", nil)
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{7 * time.Second, 7 * time.Second}, *waits, "Retry-After outlasts the backoff")
+
+	calls = 0
+	_, err = f.Get(context.Background(), srv.URL+"/missing", nil)
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	assert.Equal(t, 1, calls, "client errors aren't retried")
+	assert.Equal(t, 2, f.Stats()[0].Failures)
+}
+
+func TestFetcherConditionalGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("schedule v1"))
+	}))
+	defer srv.Close()
+
+	f, _ := testFetcher(0)
+	for i := 0; i < 2; i++ {
+		body, err := f.Get(context.Background(), srv.URL, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "schedule v1", body)
+	}
+	stats := f.Stats()[0]
+	assert.Equal(t, 2, stats.Requests)
+	assert.Equal(t, 1, stats.NotModified)
+	assert.Equal(t, int64(len("schedule v1")), stats.Bytes)
+}
+
+func TestFetcherLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	f, waits := testFetcher(0)
+	lim := NewLimiter(time.Second)
+	for i := 0; i < 3; i++ {
+		_, err := f.GetLimited(context.Background(), lim, srv.URL, nil)
+		require.NoError(t, err)
+	}
+	// The stubbed sleep doesn't pass time, so each request queues a second
+	// behind the slot the previous one reserved.
+	require.Len(t, *waits, 2)
+	assert.InDelta(t, time.Second, (*waits)[0], float64(100*time.Millisecond))
+	assert.InDelta(t, 2*time.Second, (*waits)[1], float64(100*time.Millisecond))
+	assert.Equal(t, (*waits)[0]+(*waits)[1], f.Stats()[0].Waited)
+
+	// A new limiter for the same host, as after a reload with a shorter
+	// interval, doesn't queue behind the old one, and unlimited requests
+	// never wait.
+	_, err := f.GetLimited(context.Background(), NewLimiter(10*time.Millisecond), srv.URL, nil)
+	require.NoError(t, err)
+	_, err = f.Get(context.Background(), srv.URL, nil)
+	require.NoError(t, err)
+	assert.Len(t, *waits, 2)
+}
+
+func TestHostLimiter(t *testing.T) {
+	defer ResetHostLimits()
+
+	a := HostLimiter("limited.example.com", time.Second)
+	b := HostLimiter("limited.example.com", time.Minute)
+	c := HostLimiter("limited.example.com", time.Millisecond)
+	assert.Same(t, a, b, "clients of one host share a limiter")
+	assert.Same(t, a, c)
+	assert.NotSame(t, a, HostLimiter("other.example.com", time.Second))
+	assert.Equal(t, time.Duration(0), a.reserve())
+	assert.InDelta(t, time.Minute, a.reserve(), float64(time.Second), "the longest interval applies")
+
+	// After a reset, as on reload, the clients rebuilt set a shorter interval.
+	// The slot already taken is kept; the next one is a second after it.
+	ResetHostLimits()
+	assert.Same(t, a, HostLimiter("limited.example.com", time.Second))
+	assert.InDelta(t, time.Minute+time.Second, a.reserve(), float64(time.Second))
+}
+
+func TestFetcherStopsWhenCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	f := NewFetcher(FetcherConfig{Retries: 3, Backoff: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := f.Get(ctx, srv.URL, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second, "the backoff wait is cut short")
+	assert.Equal(t, 0, f.Stats()[0].Failures, "a cancelled fetch isn't the host's failure")
+}
@@ -1,104 +1,306 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
+// DefaultPath is used when neither the -config flag nor CONFIG_PATH is set.
+const DefaultPath = "config.yaml"
+
 type Config struct {
-	Email    EmailConfig
-	Storage  StorageConfig
-	Schedule ScheduleConfig
-	Web      WebConfig
-	Leagues  map[string]LeagueConfig
+	Email    EmailConfig             `yaml:"email" json:"email"`
+	Storage  StorageConfig           `yaml:"storage" json:"storage"`
+	Schedule ScheduleConfig          `yaml:"schedule" json:"schedule"`
+	Web      WebConfig               `yaml:"web" json:"web"`
+	HTTP     HTTPConfig              `yaml:"http" json:"http"`
+	Alerts   AlertsConfig            `yaml:"alerts" json:"alerts"`
+	Leagues  map[string]LeagueConfig `yaml:"leagues" json:"leagues"`
 }
 
 type LeagueConfig struct {
-	Type         string
-	NotifyMode   string
-	ReminderTime string
-	API          map[string]string
-	Teams        []TeamEntry
+	Type         string            `yaml:"type" json:"type"`
+	Namespace    string            `yaml:"namespace" json:"namespace"`
+	NotifyMode   string            `yaml:"notify_mode" json:"notify_mode"`
+	ReminderTime string            `yaml:"reminder_time" json:"reminder_time"`
+	API          map[string]string `yaml:"api" json:"api"`
+	Teams        []TeamEntry       `yaml:"teams" json:"teams"`
+
+	// NotifyResults sends a "result posted" email when a game's score shows
+	// up, for leagues whose source publishes results (PINS).
+	NotifyResults bool `yaml:"notify_results" json:"notify_results"`
+
+	// Timezone is the IANA name (e.g. "America/Denver") of the zone the
+	// league's games are played in. Defaults to the server's local zone.
+	Timezone string `yaml:"timezone" json:"timezone"`
+
+	// GameDuration is how long a game lasts (e.g. "55m"), for calendar
+	// invites. Each league type has its own default.
+	GameDuration string `yaml:"game_duration" json:"game_duration"`
+}
+
+// Location returns the league's time zone, or time.Local when none is set.
+func (lc LeagueConfig) Location() (*time.Location, error) {
+	if lc.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(lc.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", lc.Timezone)
+	}
+	return loc, nil
+}
+
+// StorageNamespace returns the stable identifier this league's games,
+// notifications, recipients and snapshots are stored under. It defaults to a
+// slug of the league's config key, so "IVP" keeps using "ivp" and two boards
+// of the same type get separate namespaces.
+func (lc LeagueConfig) StorageNamespace(name string) string {
+	if lc.Namespace != "" {
+		return lc.Namespace
+	}
+	return Slug(name)
+}
+
+// GameLength returns the league's game duration, or def when none is set.
+func (lc LeagueConfig) GameLength(def time.Duration) (time.Duration, error) {
+	if lc.GameDuration == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(lc.GameDuration)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%q is not a positive duration", lc.GameDuration)
+	}
+	return d, nil
+}
+
+// Slug lowercases s and replaces each run of characters other than letters
+// and digits with a single "-".
+func Slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
 }
 
 type TeamEntry struct {
-	Key  string
-	Name string
-	Day  string
+	Key  string `yaml:"key" json:"key"`
+	Name string `yaml:"name" json:"name"`
+	Day  string `yaml:"day" json:"day"`
+
+	// Match is a regular expression that picks this team's events out of a
+	// shared feed, for sources that support it. Defaults to the team name.
+	Match string `yaml:"match" json:"match"`
+
+	// MatchMode picks how the team name is matched when Match is unset:
+	// "substring" (default), "exact", or "number" to match on TeamNumber,
+	// for sources that list team numbers.
+	MatchMode  string `yaml:"match_mode" json:"match_mode"`
+	TeamNumber int    `yaml:"team_number" json:"team_number"`
+
+	// Division, when set, limits matches to rows in that division (IVP),
+	// where team numbers start over in each division.
+	Division string `yaml:"division" json:"division"`
+
+	// ScheduleID and TeamID pin a PINS team's schedule and team, skipping
+	// discovery for whichever is set.
+	ScheduleID string `yaml:"schedule_id" json:"schedule_id"`
+	TeamID     string `yaml:"team_id" json:"team_id"`
 }
 
 type EmailConfig struct {
-	Enabled  bool
-	SMTPHost string
-	SMTPPort string
-	Username string
-	Password string
-	From     string
+	Enabled  bool   `yaml:"enabled" json:"enabled"`
+	SMTPHost string `yaml:"smtp_host" json:"smtp_host"`
+	SMTPPort string `yaml:"smtp_port" json:"smtp_port"`
+	Username string `yaml:"username" json:"username"`
+	Password string `yaml:"password" json:"password"`
+	From     string `yaml:"from" json:"from"`
 }
 
 type StorageConfig struct {
-	DatabasePath string
+	DatabasePath string `yaml:"database_path" json:"database_path"`
 }
 
 type ScheduleConfig struct {
-	PollInterval string
+	PollInterval  string `yaml:"poll_interval" json:"poll_interval"`
+	LeagueTimeout string `yaml:"league_timeout" json:"league_timeout"` // longest one league's fetch may take
+	MaxConcurrent int    `yaml:"max_concurrent" json:"max_concurrent"` // leagues fetched at once
 }
 
 type WebConfig struct {
-	Enabled bool
-	Port    string
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	Port    string `yaml:"port" json:"port"`
+}
+
+// HTTPConfig controls how schedule sources are fetched.
+type HTTPConfig struct {
+	UserAgent string `yaml:"user_agent" json:"user_agent"` // empty for each source's default
+	Timeout   string `yaml:"timeout" json:"timeout"`       // per attempt
+	Retries   int    `yaml:"retries" json:"retries"`       // attempts after a failed one
+}
+
+// AlertsConfig controls the emails sent to an admin when a league's source
+// keeps failing, and when it recovers. No alerts are sent without Email.
+type AlertsConfig struct {
+	Email         string `yaml:"email" json:"email"`
+	AfterFailures int    `yaml:"after_failures" json:"after_failures"` // failed polls in a row before alerting
+}
+
+// Load reads the config file at path (YAML, or JSON when the extension is
+// .json), fills in defaults for anything the file leaves out, and then
+// applies environment overrides so secrets can stay out of the file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	// Unknown keys are rejected so a typo like "notfy_mode" fails loudly
+	// instead of silently falling back to a default.
+	cfg := defaults()
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(cfg)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(cfg)
+		if err == io.EOF {
+			err = nil // empty file: defaults only
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	cfg.applyEnv()
+	return cfg, nil
 }
 
-func Load() *Config {
+// ResolvePath picks the config file location: an explicit flag value wins,
+// then CONFIG_PATH, then DefaultPath.
+func ResolvePath(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return envOr("CONFIG_PATH", DefaultPath)
+}
+
+func defaults() *Config {
 	return &Config{
 		Email: EmailConfig{
-			Enabled:  true,
-			SMTPHost: os.Getenv("SMTP_HOST"),
-			SMTPPort: os.Getenv("SMTP_PORT"),
-			Username: os.Getenv("SMTP_USERNAME"),
-			Password: os.Getenv("SMTP_PASSWORD"),
-			From:     os.Getenv("EMAIL_FROM"),
+			Enabled: true,
 		},
 		Storage: StorageConfig{
-			DatabasePath: envOr("DATABASE_PATH", "./schedule.db"),
+			DatabasePath: "./schedule.db",
 		},
 		Schedule: ScheduleConfig{
-			PollInterval: "5m",
+			PollInterval:  "5m",
+			LeagueTimeout: "2m",
+			MaxConcurrent: 4,
 		},
 		Web: WebConfig{
 			Enabled: true,
 			Port:    "8080",
 		},
-		Leagues: map[string]LeagueConfig{
-			"IVP": {
-				Type: "ivp",
-				API: map[string]string{
-					"base_url": "https://wix-visual-data.appspot.com",
-					"instance": os.Getenv("API_INSTANCE"),
-					"comp_id":  os.Getenv("API_COMP_ID"),
-				},
-				Teams: []TeamEntry{
-					{Key: "Taylor Sisneros", Name: "Taylor Sisneros"},
-				},
-			},
-			"PINS": {
-				Type:         "pins",
-				NotifyMode:   "daily_reminder",
-				ReminderTime: "08:00",
-				API: map[string]string{
-					"base_url": "https://pins.killerworld.com",
-				},
-				Teams: []TeamEntry{
-					{Key: "French Toast Mafia", Name: "French Toast Mafia", Day: "Wed"},
-				},
-			},
+		HTTP: HTTPConfig{
+			Timeout: "30s",
+			Retries: 3,
 		},
+		Alerts: AlertsConfig{
+			AfterFailures: 3,
+		},
+	}
+}
+
+// applyEnv overrides file values with environment variables when they are set.
+func (c *Config) applyEnv() {
+	overrideString(&c.Email.SMTPHost, "SMTP_HOST")
+	overrideString(&c.Email.SMTPPort, "SMTP_PORT")
+	overrideString(&c.Email.Username, "SMTP_USERNAME")
+	overrideString(&c.Email.Password, "SMTP_PASSWORD")
+	overrideString(&c.Email.From, "EMAIL_FROM")
+	overrideString(&c.Storage.DatabasePath, "DATABASE_PATH")
+	overrideString(&c.Schedule.PollInterval, "POLL_INTERVAL")
+	overrideString(&c.Web.Port, "WEB_PORT")
+	overrideString(&c.HTTP.UserAgent, "HTTP_USER_AGENT")
+	overrideString(&c.Alerts.Email, "ALERT_EMAIL")
+	overrideBool(&c.Email.Enabled, "EMAIL_ENABLED")
+	overrideBool(&c.Web.Enabled, "WEB_ENABLED")
+
+	// The Wix instance token and component ID are secrets shared by every
+	// IVP board, so they are applied to all IVP leagues.
+	for name, lg := range c.Leagues {
+		if lg.Type != "ivp" {
+			continue
+		}
+		if lg.API == nil {
+			lg.API = make(map[string]string)
+		}
+		if v := os.Getenv("API_INSTANCE"); v != "" {
+			lg.API["instance"] = v
+		}
+		if v := os.Getenv("API_COMP_ID"); v != "" {
+			lg.API["comp_id"] = v
+		}
+		c.Leagues[name] = lg
+	}
+}
+
+// GetPollInterval parses Schedule.PollInterval. Validate reports the same
+// error, so callers holding a validated config can rely on a positive value.
+func (c *Config) GetPollInterval() (time.Duration, error) {
+	d, err := time.ParseDuration(c.Schedule.PollInterval)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive, got %s", c.Schedule.PollInterval)
+	}
+	return d, nil
+}
+
+// GetLeagueTimeout parses Schedule.LeagueTimeout, which Validate also checks.
+func (c *Config) GetLeagueTimeout() (time.Duration, error) {
+	d, err := time.ParseDuration(c.Schedule.LeagueTimeout)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive, got %s", c.Schedule.LeagueTimeout)
 	}
+	return d, nil
 }
 
-func (c *Config) GetPollInterval() time.Duration {
-	d, _ := time.ParseDuration(c.Schedule.PollInterval)
-	return d
+// GetHTTPTimeout parses HTTP.Timeout, which Validate also checks.
+func (c *Config) GetHTTPTimeout() (time.Duration, error) {
+	d, err := time.ParseDuration(c.HTTP.Timeout)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive, got %s", c.HTTP.Timeout)
+	}
+	return d, nil
 }
 
 func envOr(key, fallback string) string {
@@ -107,3 +309,18 @@ func envOr(key, fallback string) string {
 	}
 	return fallback
 }
+
+func overrideString(dst *string, key string) {
+	if v := os.Getenv(key); v != "" {
+		*dst = v
+	}
+}
+
+func overrideBool(dst *bool, key string) {
+	switch strings.ToLower(os.Getenv(key)) {
+	case "1", "true", "yes", "on":
+		*dst = true
+	case "0", "false", "no", "off":
+		*dst = false
+	}
+}
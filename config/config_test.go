@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleYAML = `
+schedule:
+  poll_interval: 10m
+leagues:
+  IVP:
+    type: ivp
+    api:
+      base_url: https://wix-visual-data.appspot.com
+      instance: from-file
+    teams:
+      - key: Taylor Sisneros
+        name: Taylor Sisneros
+  PINS:
+    type: pins
+    notify_mode: daily_reminder
+    reminder_time: "08:00"
+    api:
+      base_url: https://pins.killerworld.com
+    teams:
+      - key: French Toast Mafia
+        name: French Toast Mafia
+        day: Wed
+`
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoad_YAML(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "config.yaml", sampleYAML))
+	require.NoError(t, err)
+
+	assert.Equal(t, "10m", cfg.Schedule.PollInterval)
+	require.Len(t, cfg.Leagues, 2)
+
+	pins := cfg.Leagues["PINS"]
+	assert.Equal(t, "pins", pins.Type)
+	assert.Equal(t, "daily_reminder", pins.NotifyMode)
+	assert.Equal(t, "08:00", pins.ReminderTime)
+	assert.Equal(t, "https://pins.killerworld.com", pins.API["base_url"])
+	require.Len(t, pins.Teams, 1)
+	assert.Equal(t, TeamEntry{Key: "French Toast Mafia", Name: "French Toast Mafia", Day: "Wed"}, pins.Teams[0])
+}
+
+func TestLoad_JSON(t *testing.T) {
+	content := `{"web": {"port": "9090"}, "leagues": {"IVP": {"type": "ivp", "teams": [{"key": "a", "name": "A"}]}}}`
+	cfg, err := Load(writeConfig(t, "config.json", content))
+	require.NoError(t, err)
+
+	assert.Equal(t, "9090", cfg.Web.Port)
+	assert.Equal(t, "ivp", cfg.Leagues["IVP"].Type)
+	assert.Equal(t, "a", cfg.Leagues["IVP"].Teams[0].Key)
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "config.yaml", "leagues: {}\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "5m", cfg.Schedule.PollInterval)
+	assert.Equal(t, "8080", cfg.Web.Port)
+	assert.True(t, cfg.Web.Enabled)
+	assert.True(t, cfg.Email.Enabled)
+	assert.Equal(t, "./schedule.db", cfg.Storage.DatabasePath)
+}
+
+func TestLoad_EnvOverrides(t *testing.T) {
+	t.Setenv("SMTP_PASSWORD", "secret")
+	t.Setenv("API_INSTANCE", "from-env")
+	t.Setenv("WEB_ENABLED", "false")
+
+	cfg, err := Load(writeConfig(t, "config.yaml", sampleYAML))
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret", cfg.Email.Password)
+	assert.Equal(t, "from-env", cfg.Leagues["IVP"].API["instance"])
+	assert.False(t, cfg.Web.Enabled)
+	_, hasInstance := cfg.Leagues["PINS"].API["instance"]
+	assert.False(t, hasInstance, "API_INSTANCE should only apply to IVP leagues")
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestResolvePath(t *testing.T) {
+	t.Setenv("CONFIG_PATH", "")
+	assert.Equal(t, DefaultPath, ResolvePath(""))
+
+	t.Setenv("CONFIG_PATH", "/etc/watcher.yaml")
+	assert.Equal(t, "/etc/watcher.yaml", ResolvePath(""))
+	assert.Equal(t, "flag.yaml", ResolvePath("flag.yaml"))
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Diff describes what changed between two configs, one line per change, in
+// a stable order. API values are never included since they may be secrets.
+// Changes outside of leagues are marked because they only take effect on
+// restart.
+func Diff(old, new *Config) []string {
+	var changes []string
+	add := func(format string, args ...interface{}) {
+		changes = append(changes, fmt.Sprintf(format, args...))
+	}
+
+	if old.Schedule.PollInterval != new.Schedule.PollInterval {
+		add("schedule.poll_interval: %s -> %s (requires restart)", old.Schedule.PollInterval, new.Schedule.PollInterval)
+	}
+	if old.Schedule.LeagueTimeout != new.Schedule.LeagueTimeout || old.Schedule.MaxConcurrent != new.Schedule.MaxConcurrent {
+		add("schedule settings changed (requires restart)")
+	}
+	if old.Web != new.Web {
+		add("web settings changed (requires restart)")
+	}
+	if old.Storage != new.Storage {
+		add("storage settings changed (requires restart)")
+	}
+	if old.Email != new.Email {
+		add("email settings changed (requires restart)")
+	}
+	if old.HTTP != new.HTTP {
+		add("http settings changed (requires restart)")
+	}
+	if old.Alerts != new.Alerts {
+		add("alerts settings changed (requires restart)")
+	}
+
+	for _, name := range unionKeys(old.Leagues, new.Leagues) {
+		o, inOld := old.Leagues[name]
+		n, inNew := new.Leagues[name]
+		switch {
+		case !inOld:
+			add("league %s added (%s, %d teams)", name, n.Type, len(n.Teams))
+			continue
+		case !inNew:
+			add("league %s removed", name)
+			continue
+		}
+
+		if o.Type != n.Type {
+			add("league %s: type %s -> %s", name, o.Type, n.Type)
+		}
+		if o.StorageNamespace(name) != n.StorageNamespace(name) {
+			add("league %s: namespace %s -> %s", name, o.StorageNamespace(name), n.StorageNamespace(name))
+		}
+		if o.NotifyMode != n.NotifyMode {
+			add("league %s: notify_mode %q -> %q", name, o.NotifyMode, n.NotifyMode)
+		}
+		if o.ReminderTime != n.ReminderTime {
+			add("league %s: reminder_time %q -> %q", name, o.ReminderTime, n.ReminderTime)
+		}
+		if o.Timezone != n.Timezone {
+			add("league %s: timezone %q -> %q", name, o.Timezone, n.Timezone)
+		}
+		if o.GameDuration != n.GameDuration {
+			add("league %s: game_duration %q -> %q", name, o.GameDuration, n.GameDuration)
+		}
+		if o.NotifyResults != n.NotifyResults {
+			add("league %s: notify_results %t -> %t", name, o.NotifyResults, n.NotifyResults)
+		}
+		for _, key := range unionKeys(o.API, n.API) {
+			if o.API[key] != n.API[key] {
+				add("league %s: api.%s changed", name, key)
+			}
+		}
+
+		oldTeams := make(map[string]TeamEntry)
+		for _, t := range o.Teams {
+			oldTeams[t.Key] = t
+		}
+		newTeams := make(map[string]TeamEntry)
+		for _, t := range n.Teams {
+			newTeams[t.Key] = t
+		}
+		for _, key := range unionKeys(oldTeams, newTeams) {
+			ot, inOld := oldTeams[key]
+			nt, inNew := newTeams[key]
+			switch {
+			case !inOld:
+				add("league %s: team %q added", name, key)
+			case !inNew:
+				add("league %s: team %q removed", name, key)
+			case ot != nt:
+				add("league %s: team %q changed", name, key)
+			}
+		}
+	}
+
+	return changes
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for k := range a {
+		seen[k] = true
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if !seen[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	old := validConfig()
+	old.Leagues["IVP"] = LeagueConfig{
+		Type:  "ivp",
+		API:   map[string]string{"instance": "old-secret"},
+		Teams: []TeamEntry{{Key: "ts", Name: "Taylor Sisneros"}},
+	}
+
+	new := validConfig()
+	new.Schedule.PollInterval = "10m"
+	new.Schedule.MaxConcurrent = 8
+	new.Leagues["PINS"] = LeagueConfig{
+		Type:          "pins",
+		NotifyMode:    "daily_reminder",
+		ReminderTime:  "09:00",
+		NotifyResults: true,
+		Teams: []TeamEntry{
+			{Key: "ftm", Name: "French Toast Mafia", Day: "Thu"},
+			{Key: "sets", Name: "The Sets is Great", Day: "Tue"},
+		},
+	}
+	new.Leagues["Rec"] = LeagueConfig{Type: "ical", Teams: []TeamEntry{{Key: "a", Name: "A"}}}
+
+	assert.Equal(t, []string{
+		"schedule.poll_interval: 5m -> 10m (requires restart)",
+		"schedule settings changed (requires restart)",
+		"league IVP removed",
+		`league PINS: reminder_time "08:00" -> "09:00"`,
+		"league PINS: notify_results false -> true",
+		`league PINS: team "ftm" changed`,
+		`league PINS: team "sets" added`,
+		"league Rec added (ical, 1 teams)",
+	}, Diff(old, new))
+}
+
+func TestDiff_HidesAPIValues(t *testing.T) {
+	old := validConfig()
+	old.Leagues["PINS"] = LeagueConfig{Type: "pins", API: map[string]string{"token": "old-secret"}}
+	new := validConfig()
+	new.Leagues["PINS"] = LeagueConfig{Type: "pins", API: map[string]string{"token": "new-secret"}}
+
+	changes := Diff(old, new)
+	assert.Equal(t, []string{"league PINS: api.token changed"}, changes)
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	assert.Empty(t, Diff(validConfig(), validConfig()))
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var reminderTimeRe = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`)
+
+// ValidationError lists every problem found in a config so they can all be
+// fixed in one pass instead of one restart at a time.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	if len(e.Problems) == 1 {
+		b.WriteString("invalid configuration (1 problem):")
+	} else {
+		fmt.Fprintf(&b, "invalid configuration (%d problems):", len(e.Problems))
+	}
+	for _, p := range e.Problems {
+		b.WriteString("\n  - ")
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// LeagueValidator checks the source-specific parts of a league config and
+// returns a description of each problem found. It lets packages that know
+// about league types extend Validate without config importing them.
+type LeagueValidator func(name string, lg LeagueConfig) []string
+
+// Validate checks the whole config and returns a *ValidationError listing
+// every problem, or nil if the config is usable.
+func (c *Config) Validate(leagueChecks ...LeagueValidator) error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if _, err := c.GetPollInterval(); err != nil {
+		add("schedule.poll_interval: %v", err)
+	}
+	if _, err := c.GetLeagueTimeout(); err != nil {
+		add("schedule.league_timeout: %v", err)
+	}
+	if c.Schedule.MaxConcurrent < 1 {
+		add("schedule.max_concurrent: %d must be at least 1", c.Schedule.MaxConcurrent)
+	}
+
+	if _, err := c.GetHTTPTimeout(); err != nil {
+		add("http.timeout: %v", err)
+	}
+	if c.HTTP.Retries < 0 {
+		add("http.retries: %d is negative", c.HTTP.Retries)
+	}
+
+	if c.Alerts.Email != "" {
+		if _, err := mail.ParseAddress(c.Alerts.Email); err != nil {
+			add("alerts.email: %q is not an email address", c.Alerts.Email)
+		}
+	}
+	if c.Alerts.AfterFailures < 1 {
+		add("alerts.after_failures: %d must be at least 1", c.Alerts.AfterFailures)
+	}
+
+	if c.Storage.DatabasePath == "" {
+		add("storage.database_path is required")
+	}
+
+	if c.Web.Enabled {
+		if port, err := strconv.Atoi(c.Web.Port); err != nil || port <= 0 || port > 65535 {
+			add("web.port: %q is not a valid port", c.Web.Port)
+		}
+	}
+
+	if c.Email.Enabled {
+		if c.Email.SMTPHost == "" {
+			add("email.smtp_host is required when email is enabled (set SMTP_HOST)")
+		}
+		if c.Email.SMTPPort == "" {
+			add("email.smtp_port is required when email is enabled (set SMTP_PORT)")
+		}
+		if c.Email.From == "" {
+			add("email.from is required when email is enabled (set EMAIL_FROM)")
+		}
+	}
+
+	if len(c.Leagues) == 0 {
+		add("leagues: at least one league must be configured")
+	}
+
+	// Sort names so the report is stable between runs.
+	names := make([]string, 0, len(c.Leagues))
+	for name := range c.Leagues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	namespaces := make(map[string]string)
+	for _, name := range names {
+		lg := c.Leagues[name]
+		prefix := fmt.Sprintf("leagues.%s", name)
+
+		ns := lg.StorageNamespace(name)
+		switch {
+		case ns == "":
+			add("%s.namespace is required when the league name has no letters or digits", prefix)
+		case strings.Contains(ns, ":"):
+			add("%s.namespace: %q must not contain ':'", prefix, ns)
+		case namespaces[ns] != "":
+			add("%s: storage namespace %q is already used by league %s; set a distinct namespace", prefix, ns, namespaces[ns])
+		default:
+			namespaces[ns] = name
+		}
+
+		if lg.Type == "" {
+			add("%s.type is required", prefix)
+		}
+		if lg.ReminderTime != "" && !reminderTimeRe.MatchString(lg.ReminderTime) {
+			add("%s.reminder_time: %q is not a 24-hour HH:MM time", prefix, lg.ReminderTime)
+		}
+		if _, err := lg.Location(); err != nil {
+			add("%s.timezone: %v", prefix, err)
+		}
+		if _, err := lg.GameLength(0); err != nil {
+			add("%s.game_duration: %v", prefix, err)
+		}
+		if len(lg.Teams) == 0 {
+			add("%s.teams: at least one team is required", prefix)
+		}
+
+		seen := make(map[string]bool)
+		for i, t := range lg.Teams {
+			teamPrefix := fmt.Sprintf("%s.teams[%d]", prefix, i)
+			if t.Key == "" {
+				add("%s.key is required", teamPrefix)
+			} else if seen[t.Key] {
+				add("%s.key: duplicate team key %q", teamPrefix, t.Key)
+			}
+			seen[t.Key] = true
+			if strings.Contains(t.Key, ":") {
+				add("%s.key: %q must not contain ':'", teamPrefix, t.Key)
+			}
+			if t.Name == "" {
+				add("%s.name is required", teamPrefix)
+			}
+			if t.Day != "" {
+				if _, ok := ParseWeekday(t.Day); !ok {
+					add("%s.day: %q is not a day of the week", teamPrefix, t.Day)
+				}
+			}
+		}
+
+		for _, check := range leagueChecks {
+			for _, p := range check(name, lg) {
+				add("%s: %s", prefix, p)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// ParseWeekday accepts a full day name or an abbreviation of at least three
+// letters ("Wed", "Thurs", "Wednesday"), case-insensitively.
+func ParseWeekday(s string) (time.Weekday, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) < 3 {
+		return 0, false
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.HasPrefix(strings.ToLower(d.String()), s) {
+			return d, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig() *Config {
+	cfg := defaults()
+	cfg.Email.SMTPHost = "smtp.example.com"
+	cfg.Email.SMTPPort = "587"
+	cfg.Email.From = "watcher@example.com"
+	cfg.Leagues = map[string]LeagueConfig{
+		"PINS": {
+			Type:         "pins",
+			NotifyMode:   "daily_reminder",
+			ReminderTime: "08:00",
+			Teams:        []TeamEntry{{Key: "ftm", Name: "French Toast Mafia", Day: "Wed"}},
+		},
+	}
+	return cfg
+}
+
+func TestValidate_Valid(t *testing.T) {
+	assert.NoError(t, validConfig().Validate())
+}
+
+func TestValidate_CollectsEveryProblem(t *testing.T) {
+	cfg := validConfig()
+	cfg.Schedule.PollInterval = "5 minutes"
+	cfg.Schedule.LeagueTimeout = "0s"
+	cfg.Schedule.MaxConcurrent = 0
+	cfg.Web.Port = "http"
+	cfg.HTTP.Timeout = "soon"
+	cfg.HTTP.Retries = -1
+	cfg.Alerts.Email = "admin at example"
+	cfg.Alerts.AfterFailures = 0
+	cfg.Leagues["PINS"] = LeagueConfig{
+		Type:         "pins",
+		ReminderTime: "8am",
+		Timezone:     "Mountain",
+		GameDuration: "an hour",
+		Teams: []TeamEntry{
+			{Key: "ftm", Name: "French Toast Mafia", Day: "Wendesday"},
+			{Key: "ftm", Name: ""},
+		},
+	}
+	cfg.Leagues["IVP"] = LeagueConfig{}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	msg := err.Error()
+	for _, want := range []string{
+		"schedule.poll_interval",
+		"schedule.league_timeout: must be positive",
+		"schedule.max_concurrent: 0 must be at least 1",
+		"web.port",
+		"http.timeout",
+		"http.retries: -1 is negative",
+		`alerts.email: "admin at example" is not an email address`,
+		"alerts.after_failures: 0 must be at least 1",
+		"leagues.IVP.type is required",
+		"leagues.IVP.teams: at least one team is required",
+		`leagues.PINS.reminder_time: "8am"`,
+		`leagues.PINS.timezone: unknown time zone "Mountain"`,
+		`leagues.PINS.game_duration: "an hour" is not a positive duration`,
+		`leagues.PINS.teams[0].day: "Wendesday"`,
+		`leagues.PINS.teams[1].key: duplicate team key "ftm"`,
+		"leagues.PINS.teams[1].name is required",
+	} {
+		assert.Contains(t, msg, want)
+	}
+	assert.Len(t, verr.Problems, 16)
+	// Leagues are reported in name order so the output is stable.
+	assert.Less(t, strings.Index(msg, "leagues.IVP"), strings.Index(msg, "leagues.PINS"))
+}
+
+func TestValidate_ZeroPollInterval(t *testing.T) {
+	cfg := validConfig()
+	cfg.Schedule.PollInterval = "0s"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestValidate_EmailRequiresSMTP(t *testing.T) {
+	cfg := validConfig()
+	cfg.Email.SMTPHost = ""
+	assert.ErrorContains(t, cfg.Validate(), "email.smtp_host")
+
+	cfg.Email.Enabled = false
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_LeagueValidators(t *testing.T) {
+	cfg := validConfig()
+	check := func(name string, lg LeagueConfig) []string {
+		return []string{"api.base_url is required"}
+	}
+	assert.ErrorContains(t, cfg.Validate(check), "leagues.PINS: api.base_url is required")
+}
+
+func TestParseWeekday(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Weekday
+		ok   bool
+	}{
+		{"Wed", time.Wednesday, true},
+		{"wednesday", time.Wednesday, true},
+		{"Thurs", time.Thursday, true},
+		{"SUN", time.Sunday, true},
+		{"We", 0, false},
+		{"Wendesday", 0, false},
+		{"Funday", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseWeekday(tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+		if tt.ok {
+			assert.Equal(t, tt.want, got, tt.in)
+		}
+	}
+}
+
+func TestValidate_DuplicateNamespace(t *testing.T) {
+	cfg := validConfig()
+	cfg.Leagues["IVP Tuesday"] = LeagueConfig{Type: "ivp", Namespace: "pins", Teams: []TeamEntry{{Key: "a", Name: "A"}}}
+
+	assert.ErrorContains(t, cfg.Validate(), `leagues.PINS: storage namespace "pins" is already used by league IVP Tuesday`)
+}
+
+func TestStorageNamespace(t *testing.T) {
+	assert.Equal(t, "ivp", LeagueConfig{}.StorageNamespace("IVP"))
+	assert.Equal(t, "ivp-thursday", LeagueConfig{}.StorageNamespace("IVP (Thursday)"))
+	assert.Equal(t, "custom", LeagueConfig{Namespace: "custom"}.StorageNamespace("IVP"))
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"gopkg.in/yaml.v3"
+)
+
+// runDiscover implements the discover subcommand. For each configured league
+// whose source supports it (league.Discoverer), it prints the seasons and
+// teams the source knows about, and with -snippet the team entries to paste
+// into the config. The config's teams don't need to be filled in yet.
+func runDiscover(path string, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("discover", flag.ExitOnError)
+	only := fs.String("league", "", "only discover the league with this config name")
+	teamFilter := fs.String("team", "", "only list teams whose name contains this text")
+	all := fs.Bool("all", false, "also list the teams of past seasons")
+	snippet := fs.Bool("snippet", false, "print a config snippet for the listed teams")
+	fs.Parse(args)
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	configureFetcher(cfg)
+
+	names := make([]string, 0, len(cfg.Leagues))
+	for name := range cfg.Leagues {
+		if *only == "" || name == *only {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no league named %q in %s", *only, path)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		lgCfg := cfg.Leagues[name]
+		fmt.Fprintf(w, "== %s (%s) ==\n", name, lgCfg.Type)
+
+		lg, err := league.Build(name, lgCfg)
+		if err != nil {
+			fmt.Fprintf(w, "  %v\n\n", err)
+			continue
+		}
+		d, ok := lg.(league.Discoverer)
+		if !ok {
+			fmt.Fprintf(w, "  %s leagues don't support discovery\n\n", lgCfg.Type)
+			continue
+		}
+
+		seasons, err := d.Discover(context.Background(), *all)
+		if err != nil {
+			fmt.Fprintf(w, "  discovery failed: %v\n\n", err)
+			continue
+		}
+		for i := range seasons {
+			seasons[i].Teams = filterTeams(seasons[i].Teams, *teamFilter)
+		}
+
+		printSeasons(w, seasons)
+		if *snippet {
+			if err := printSnippet(w, seasons); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func filterTeams(teams []league.DiscoveredTeam, filter string) []league.DiscoveredTeam {
+	if filter == "" {
+		return teams
+	}
+	var matched []league.DiscoveredTeam
+	for _, t := range teams {
+		if strings.Contains(strings.ToLower(t.Name), strings.ToLower(filter)) {
+			matched = append(matched, t)
+		}
+	}
+	return matched
+}
+
+func printSeasons(w io.Writer, seasons []league.DiscoveredSeason) {
+	for _, s := range seasons {
+		header := s.Name
+		if s.ID != "" {
+			header = fmt.Sprintf("%s (schedule_id %s)", s.Name, s.ID)
+		}
+		var notes []string
+		if s.Day != "" {
+			notes = append(notes, "day "+s.Day)
+		}
+		if s.Past {
+			notes = append(notes, "past")
+		}
+		if len(notes) > 0 {
+			header += " [" + strings.Join(notes, ", ") + "]"
+		}
+		fmt.Fprintf(w, "  %s\n", header)
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, t := range s.Teams {
+			number := ""
+			if t.Number != 0 {
+				number = fmt.Sprintf("#%d", t.Number)
+			}
+			id := ""
+			if t.ID != "" {
+				id = "team_id " + t.ID
+			}
+			fmt.Fprintf(tw, "    %s\t%s\t%s\t%s\n", number, t.Name, t.Division, id)
+		}
+		tw.Flush()
+	}
+}
+
+// snippetTeam is a config.TeamEntry with only the fields discovery fills in.
+type snippetTeam struct {
+	Key        string `yaml:"key"`
+	Name       string `yaml:"name"`
+	Day        string `yaml:"day,omitempty"`
+	ScheduleID string `yaml:"schedule_id,omitempty"`
+	TeamID     string `yaml:"team_id,omitempty"`
+}
+
+// printSnippet prints a teams list for the teams of current and upcoming
+// seasons. Teams are found by name and day where the source allows;
+// seasons with no known day are pinned by ID instead.
+func printSnippet(w io.Writer, seasons []league.DiscoveredSeason) error {
+	var entries []snippetTeam
+	seen := make(map[string]bool)
+	keys := make(map[string]bool)
+	for _, s := range seasons {
+		if s.Past {
+			continue
+		}
+		for _, t := range s.Teams {
+			id := t.Name + "|" + s.Day
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			entry := snippetTeam{Name: t.Name, Day: s.Day}
+			if s.Day == "" && s.ID != "" {
+				entry.ScheduleID = s.ID
+				entry.TeamID = t.ID
+			}
+
+			entry.Key = config.Slug(t.Name)
+			if keys[entry.Key] && s.Day != "" {
+				entry.Key += "-" + config.Slug(s.Day)
+			}
+			for n := 2; keys[entry.Key]; n++ {
+				entry.Key = fmt.Sprintf("%s-%d", config.Slug(t.Name), n)
+			}
+			keys[entry.Key] = true
+
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(map[string][]snippetTeam{"teams": entries}); err != nil {
+		return fmt.Errorf("writing config snippet: %w", err)
+	}
+
+	fmt.Fprintln(w, "\n  # config snippet:")
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		fmt.Fprintf(w, "    %s\n", line)
+	}
+	return nil
+}
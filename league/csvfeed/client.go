@@ -0,0 +1,22 @@
+package csvfeed
+
+import (
+	"context"
+
+	"github.com/aweist/schedule-watcher/client"
+)
+
+type CSVClient struct {
+	fetcher *client.Fetcher
+}
+
+func NewClient() *CSVClient {
+	return &CSVClient{
+		fetcher: client.Default(),
+	}
+}
+
+// FetchCSV downloads a published CSV.
+func (c *CSVClient) FetchCSV(ctx context.Context, url string) (string, error) {
+	return c.fetcher.Get(ctx, url, map[string]string{"Accept": "text/csv"})
+}
@@ -0,0 +1,164 @@
+package csvfeed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/league/fieldmap"
+	"github.com/aweist/schedule-watcher/models"
+)
+
+// columnSuffix is appended to a field name to form its api key
+// (e.g. "date_column").
+const columnSuffix = "_column"
+
+func init() {
+	required, optional := fieldmap.APIKeys(columnSuffix, fieldmap.Team, fieldmap.Date)
+	league.Register("csv", league.Type{
+		Description: "Any published CSV (Google Sheets export, Wix table) with one game per row, columns mapped in config",
+		RequiredAPI: append([]string{"url"}, required...),
+		OptionalAPI: append(optional, "header_row"),
+		Validate: func(cfg config.LeagueConfig) []string {
+			problems := fieldmap.FromAPI(cfg.API, columnSuffix).Validate()
+			if v := cfg.API["header_row"]; v != "" {
+				if n, err := strconv.Atoi(v); err != nil || n < 1 {
+					problems = append(problems, fmt.Sprintf("api.header_row: %q is not a positive line number", v))
+				}
+			}
+			return append(problems, league.ValidateMatchPatterns(cfg)...)
+		},
+		Factory: func(name string, cfg config.LeagueConfig) (league.League, error) {
+			lg, err := New(name, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return lg, nil
+		},
+	})
+}
+
+type CSVLeague struct {
+	name         string
+	displayName  string
+	notifyMode   string
+	reminderTime string
+	url          string
+	headerRow    int
+	mapping      fieldmap.Mapping
+	client       *CSVClient
+	teams        []league.TeamConfig
+	matchers     []league.TeamMatcher
+	lastRawCSV   string
+}
+
+func New(name string, cfg config.LeagueConfig) (*CSVLeague, error) {
+	url := cfg.API["url"]
+	if url == "" {
+		return nil, fmt.Errorf("api.url is required for CSV league")
+	}
+
+	headerRow := 1
+	if v := cfg.API["header_row"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("api.header_row: %q is not a positive line number", v)
+		}
+		headerRow = n
+	}
+
+	var teams []league.TeamConfig
+	for _, t := range cfg.Teams {
+		teams = append(teams, league.TeamConfig{
+			Key:  t.Key,
+			Name: t.Name,
+		})
+	}
+
+	matchers, err := league.NewTeamMatchers(cfg.Teams)
+	if err != nil {
+		return nil, err
+	}
+	location, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+	mapping := fieldmap.FromAPI(cfg.API, columnSuffix)
+	mapping.Location = location
+	mapping.Duration, err = cfg.GameLength(fieldmap.DefaultGameDuration)
+	if err != nil {
+		return nil, fmt.Errorf("game_duration: %w", err)
+	}
+
+	notifyMode := cfg.NotifyMode
+	if notifyMode == "" {
+		notifyMode = league.NotifyImmediate
+	}
+
+	return &CSVLeague{
+		name:         cfg.StorageNamespace(name),
+		displayName:  name,
+		notifyMode:   notifyMode,
+		reminderTime: cfg.ReminderTime,
+		url:          url,
+		headerRow:    headerRow,
+		mapping:      mapping,
+		client:       NewClient(),
+		teams:        teams,
+		matchers:     matchers,
+	}, nil
+}
+
+func (l *CSVLeague) Name() string               { return l.name }
+func (l *CSVLeague) DisplayName() string        { return l.displayName }
+func (l *CSVLeague) NotifyMode() string         { return l.notifyMode }
+func (l *CSVLeague) ReminderTime() string       { return l.reminderTime }
+func (l *CSVLeague) Teams() []league.TeamConfig { return l.teams }
+func (l *CSVLeague) LastRawData() string        { return l.lastRawCSV }
+func (l *CSVLeague) Location() *time.Location   { return l.mapping.Location }
+
+// GameTimes implements league.GameTimer.
+func (l *CSVLeague) GameTimes(game models.Game) (time.Time, time.Time, bool) {
+	return l.mapping.GameTimes(game)
+}
+
+func (l *CSVLeague) FetchAndParse(ctx context.Context) (map[string][]models.Game, error) {
+	data, err := l.client.FetchCSV(ctx, l.url)
+	if err != nil {
+		return nil, fmt.Errorf("fetching CSV: %w", err)
+	}
+	l.lastRawCSV = data
+
+	return l.ParseRaw(data)
+}
+
+// ParseRaw implements league.RawParser for a CSV export.
+func (l *CSVLeague) ParseRaw(data string) (map[string][]models.Game, error) {
+	table, err := ParseTable(data, l.headerRow)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CSV: %w", err)
+	}
+	if err := table.CheckColumns(l.mapping); err != nil {
+		return nil, fmt.Errorf("CSV columns: %w", err)
+	}
+
+	result := make(map[string][]models.Game)
+	for _, m := range l.matchers {
+		games, errs := table.Games(l.mapping, m, "csv")
+		for _, err := range errs {
+			log.Printf("CSV: skipping %s row for team %s: %v", l.displayName, m.Entry.Key, err)
+		}
+		for i := range games {
+			games[i].League = l.name
+			games[i].LeagueName = l.displayName
+		}
+		log.Printf("CSV: found %d games for team %s in %s", len(games), m.Entry.Key, l.displayName)
+		result[m.Entry.Key] = games
+	}
+
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package csvfeed
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/aweist/schedule-watcher/league/fieldmap"
+)
+
+// ParseTable reads CSV data whose headers are on the given 1-based line.
+// Lines above the headers (sheet titles and the like) are skipped.
+func ParseTable(data string, headerRow int) (*fieldmap.Table, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV: %w", err)
+	}
+
+	if headerRow < 1 {
+		headerRow = 1
+	}
+	if len(records) < headerRow {
+		return nil, fmt.Errorf("CSV has %d rows, header row is %d", len(records), headerRow)
+	}
+
+	return &fieldmap.Table{
+		Headers: records[headerRow-1],
+		Rows:    records[headerRow:],
+	}, nil
+}
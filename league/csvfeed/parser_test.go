@@ -0,0 +1,85 @@
+package csvfeed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/league/fieldmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleSheet = `Spring 2026 Coed Rec League,,,,,
+Date,Time,Field,Home,Away,Division
+3/10/2026,7:00 PM,Court 1,Dig Dug,Net Results,Rec B
+3/10/2026,8:00 PM,Court 2,Spike Lee,Block Party,Rec B
+3/17/2026,7:00 PM,Court 2,Block Party,Dig Dug,Rec B
+TBD,,,Dig Dug,Spike Lee,Rec B
+`
+
+func sampleMapping() fieldmap.Mapping {
+	return fieldmap.FromAPI(map[string]string{
+		"team_column":     "Home|Away",
+		"date_column":     "Date",
+		"time_column":     "Time",
+		"court_column":    "Field",
+		"division_column": "#6",
+		"time_format":     "3:04 PM",
+	}, columnSuffix)
+}
+
+func digDug(t *testing.T) league.TeamMatcher {
+	m, err := league.NewTeamMatcher(config.TeamEntry{Key: "digdug", Name: "Dig Dug"})
+	require.NoError(t, err)
+	return m
+}
+
+func TestParseTableHeaderRow(t *testing.T) {
+	table, err := ParseTable(sampleSheet, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Date", table.Headers[0])
+	assert.Len(t, table.Rows, 4)
+
+	i, ok := table.Column("field")
+	assert.True(t, ok)
+	assert.Equal(t, 2, i)
+
+	i, ok = table.Column("#6")
+	assert.True(t, ok)
+	assert.Equal(t, 5, i)
+}
+
+func TestCheckColumns(t *testing.T) {
+	table, err := ParseTable(sampleSheet, 2)
+	require.NoError(t, err)
+	assert.NoError(t, table.CheckColumns(sampleMapping()))
+
+	m := sampleMapping()
+	m.Sources[fieldmap.Court] = "Court"
+	err = table.CheckColumns(m)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `court column "Court"`)
+}
+
+func TestTableGames(t *testing.T) {
+	table, err := ParseTable(sampleSheet, 2)
+	require.NoError(t, err)
+
+	games, errs := table.Games(sampleMapping(), digDug(t), "csv")
+	require.Len(t, games, 2)
+	require.Len(t, errs, 1, "the TBD row is reported, not dropped silently")
+
+	g := games[0]
+	assert.Equal(t, "csv", g.LeagueType)
+	assert.Equal(t, "digdug", g.TeamKey)
+	assert.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local), g.Date)
+	assert.Equal(t, "7:00 pm", g.Time)
+	assert.Equal(t, "1", g.Court)
+	assert.Equal(t, "Net Results", g.Opponent)
+	assert.Equal(t, "Rec B", g.Division)
+
+	assert.Equal(t, "Block Party", games[1].Opponent)
+	assert.NotEqual(t, games[0].ID, games[1].ID)
+}
@@ -0,0 +1,27 @@
+package league
+
+import "context"
+
+// Discoverer is optionally implemented by leagues that can list the seasons
+// and teams their source knows about, to help fill in team config. Past
+// seasons are listed without their teams unless allSeasons is set.
+type Discoverer interface {
+	Discover(ctx context.Context, allSeasons bool) ([]DiscoveredSeason, error)
+}
+
+// DiscoveredSeason is one season (or schedule) offered by a source.
+type DiscoveredSeason struct {
+	ID    string // source's season ID (e.g., a PINS SCHEDULE_ID), if any
+	Name  string
+	Day   string // day of week the season plays, if known
+	Past  bool   // ended before today
+	Teams []DiscoveredTeam
+}
+
+// DiscoveredTeam is one team listed in a season.
+type DiscoveredTeam struct {
+	ID       string // source's team ID (e.g., a PINS TEAM_ID), if any
+	Name     string // name as the source lists it (the captain for IVP)
+	Number   int    // team number, if any
+	Division string
+}
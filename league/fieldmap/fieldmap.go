@@ -0,0 +1,332 @@
+// Package fieldmap turns config-described records (CSV rows, HTML table rows,
+// JSON objects) into games. Each source type decides what a "source" string
+// means: a column header for tables, a path for JSON.
+package fieldmap
+
+import (
+	"crypto/md5"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/models"
+	"github.com/aweist/schedule-watcher/parser"
+)
+
+// Game fields a mapping can fill.
+const (
+	Team     = "team"
+	Date     = "date"
+	Time     = "time"
+	Court    = "court"
+	Opponent = "opponent"
+	Division = "division"
+)
+
+// Fields lists the mappable game fields in display order.
+var Fields = []string{Team, Date, Time, Court, Opponent, Division}
+
+// now is stubbed in tests to place dates listed without a year.
+var now = time.Now
+
+const (
+	DefaultDateFormat = "1/2/2006"
+	outputTimeFormat  = "3:04 pm"
+
+	// DefaultGameDuration is the game length used when a league doesn't
+	// set one.
+	DefaultGameDuration = time.Hour
+
+	// UnixDate is a DateFormat for dates given as Unix seconds.
+	UnixDate = "unix"
+)
+
+// Getter returns the value a record holds for a source, or "" if it has
+// none.
+type Getter func(source string) string
+
+// Mapping says where each game field comes from.
+type Mapping struct {
+	// Sources maps a field (Team, Date, ...) to its source. The team source
+	// may list alternatives separated by "|" (e.g. "Home|Away"); the first
+	// one naming the team is used and, without an opponent source, the
+	// other becomes the opponent.
+	Sources map[string]string
+
+	// DateFormat is the Go time layout of the date source, or UnixDate for
+	// seconds since the epoch. Layouts without a year get the year that puts
+	// the date nearest today.
+	// When the layout includes a clock time and no time source is mapped,
+	// the game time comes from the date.
+	DateFormat string
+
+	// TimeFormat is the Go time layout of the time source. When empty the
+	// time is passed through as-is.
+	TimeFormat string
+
+	// Location is the time zone games are in; dates without a zone are read
+	// in it and those with one are converted to it. nil means time.Local.
+	Location *time.Location
+
+	// Duration is how long each game lasts; 0 means DefaultGameDuration.
+	Duration time.Duration
+}
+
+func (m Mapping) duration() time.Duration {
+	if m.Duration <= 0 {
+		return DefaultGameDuration
+	}
+	return m.Duration
+}
+
+// GameTimes returns when a game built by this mapping starts and ends, from
+// its date and time. Times without AM/PM are read on a 24-hour clock.
+func (m Mapping) GameTimes(game models.Game) (time.Time, time.Time, bool) {
+	start, ok := models.ParseClock(game.Date, game.Time, m.location(), false)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(m.duration()), true
+}
+
+func (m Mapping) location() *time.Location {
+	if m.Location == nil {
+		return time.Local
+	}
+	return m.Location
+}
+
+// FromAPI reads a mapping from a league's api settings, where each field's
+// source is stored under "<field><suffix>" (e.g. "date_column") and the
+// formats under "date_format" and "time_format".
+func FromAPI(api map[string]string, suffix string) Mapping {
+	m := Mapping{
+		Sources:    make(map[string]string),
+		DateFormat: api["date_format"],
+		TimeFormat: api["time_format"],
+	}
+	for _, f := range Fields {
+		if v := strings.TrimSpace(api[f+suffix]); v != "" {
+			m.Sources[f] = v
+		}
+	}
+	if m.DateFormat == "" {
+		m.DateFormat = DefaultDateFormat
+	}
+	return m
+}
+
+// APIKeys returns the api keys a source type using suffix understands, for
+// league.Type's RequiredAPI and OptionalAPI. The given fields are required,
+// the rest optional.
+func APIKeys(suffix string, requiredFields ...string) (required, optional []string) {
+	isRequired := make(map[string]bool)
+	for _, f := range requiredFields {
+		isRequired[f] = true
+	}
+	for _, f := range Fields {
+		if isRequired[f] {
+			required = append(required, f+suffix)
+		} else {
+			optional = append(optional, f+suffix)
+		}
+	}
+	optional = append(optional, "date_format", "time_format")
+	return required, optional
+}
+
+// Validate checks the formats parse a sample time, catching layouts written
+// in another convention (e.g. "MM/DD/YYYY").
+func (m Mapping) Validate() []string {
+	var problems []string
+	if m.DateFormat != UnixDate && !isLayout(m.DateFormat) {
+		problems = append(problems, fmt.Sprintf("api.date_format: %q is not a Go time layout (e.g. %q)", m.DateFormat, DefaultDateFormat))
+	}
+	if m.TimeFormat != "" && !isLayout(m.TimeFormat) {
+		problems = append(problems, fmt.Sprintf("api.time_format: %q is not a Go time layout (e.g. %q)", m.TimeFormat, "3:04 PM"))
+	}
+	return problems
+}
+
+// isLayout reports whether a layout contains at least one time element.
+func isLayout(layout string) bool {
+	ref := time.Date(2009, 11, 10, 23, 4, 5, 0, time.UTC)
+	return ref.Format(layout) != layout
+}
+
+// TeamSources returns the alternatives listed for the team field.
+func (m Mapping) TeamSources() []string {
+	var sources []string
+	for _, s := range strings.Split(m.Sources[Team], "|") {
+		if s = strings.TrimSpace(s); s != "" {
+			sources = append(sources, s)
+		}
+	}
+	return sources
+}
+
+// Game builds a game from a record if one of its team sources satisfies
+// isTeam. ok is false when the record isn't the team's; err is set when it
+// is but its date can't be read. Without a team source every record is the
+// team's (a page listing a single team's games) and TeamCaptain is left
+// empty for the caller to fill in.
+func (m Mapping) Game(get Getter, isTeam func(string) bool) (game models.Game, ok bool, err error) {
+	teamSources := m.TeamSources()
+	teamIdx := -1
+	for i, src := range teamSources {
+		if v := strings.TrimSpace(get(src)); v != "" && isTeam(v) {
+			teamIdx = i
+			break
+		}
+	}
+	if teamIdx == -1 && len(teamSources) > 0 {
+		return models.Game{}, false, nil
+	}
+
+	field := func(f string) string {
+		src, mapped := m.Sources[f]
+		if !mapped {
+			return ""
+		}
+		return strings.TrimSpace(get(src))
+	}
+
+	game = models.Game{
+		Court:    strings.TrimPrefix(field(Court), "Court "),
+		Opponent: field(Opponent),
+		Division: field(Division),
+		Timezone: m.location().String(),
+	}
+	if teamIdx >= 0 {
+		game.TeamCaptain = strings.TrimSpace(get(teamSources[teamIdx]))
+	}
+	if _, mapped := m.Sources[Opponent]; !mapped && len(teamSources) == 2 {
+		game.Opponent = strings.TrimSpace(get(teamSources[1-teamIdx]))
+	}
+
+	dateStr := field(Date)
+	date, hasClock, err := m.parseDate(dateStr)
+	if err != nil {
+		return models.Game{}, true, err
+	}
+	game.Date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+
+	switch {
+	case m.Sources[Time] != "":
+		game.Time = m.formatTime(field(Time))
+		game.StartsAt, game.EndsAt, _ = m.GameTimes(game)
+	case hasClock:
+		game.Time = date.Format(outputTimeFormat)
+		game.StartsAt = date
+		game.EndsAt = date.Add(m.duration())
+	}
+
+	game.Raw = m.raw(get)
+	return game, true, nil
+}
+
+func (m Mapping) parseDate(s string) (time.Time, bool, error) {
+	layout := m.DateFormat
+	if layout == "" {
+		layout = DefaultDateFormat
+	}
+	if s == "" {
+		return time.Time{}, false, fmt.Errorf("empty date")
+	}
+
+	if layout == UnixDate {
+		secs, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("parsing date %q as Unix seconds: %w", s, err)
+		}
+		return time.Unix(secs, 0).In(m.location()), true, nil
+	}
+
+	t, err := time.ParseInLocation(layout, s, m.location())
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("parsing date %q with layout %q: %w", s, layout, err)
+	}
+	if t.Year() == 0 {
+		year := parser.ClosestYear(t.Month(), t.Day(), now().In(m.location()))
+		t = time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	}
+	// Layouts with a zone offset yield that zone; games are kept in the
+	// league's.
+	t = t.In(m.location())
+
+	ref := time.Date(2009, 11, 10, 0, 0, 0, 0, time.UTC)
+	hasClock := ref.Format(layout) != ref.Add(15*time.Hour+4*time.Minute).Format(layout)
+	return t, hasClock, nil
+}
+
+// formatTime normalizes a time to "3:04 pm" when a time format is set and
+// the value parses; anything else is kept verbatim.
+func (m Mapping) formatTime(s string) string {
+	if m.TimeFormat == "" || s == "" {
+		return s
+	}
+	t, err := time.Parse(m.TimeFormat, strings.ToUpper(s))
+	if err != nil {
+		t, err = time.Parse(m.TimeFormat, s)
+		if err != nil {
+			return s
+		}
+	}
+	return t.Format(outputTimeFormat)
+}
+
+// raw records the mapped values so snapshots and debugging show what the
+// game was built from.
+func (m Mapping) raw(get Getter) string {
+	fields := make([]string, 0, len(m.Sources))
+	for f := range m.Sources {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+
+	var parts []string
+	for _, f := range fields {
+		for _, src := range strings.Split(m.Sources[f], "|") {
+			parts = append(parts, strings.TrimSpace(get(strings.TrimSpace(src))))
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+// Games maps each record naming the team to a game tagged with leagueType,
+// which also prefixes the IDs. Records whose date can't be parsed are skipped
+// and reported in the returned errors, numbered from 1.
+func Games(records []Getter, m Mapping, team league.TeamMatcher, leagueType string) ([]models.Game, []error) {
+	var games []models.Game
+	var errs []error
+	for i, get := range records {
+		game, ok, err := m.Game(get, team.Matches)
+		if !ok {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("row %d: %w", i+1, err))
+			continue
+		}
+		if game.TeamCaptain == "" {
+			game.TeamCaptain = team.Entry.Name
+		}
+		game.TeamKey = team.Entry.Key
+		game.LeagueType = leagueType
+		game.ID = GameID(leagueType, team.Entry.Key, game)
+		games = append(games, game)
+	}
+	return games, errs
+}
+
+// GameID returns a stable ID for a game built by a mapping. Like the IVP IDs
+// it is derived from team, date, time and court, so a moved game shows up as
+// a new one.
+func GameID(prefix, teamKey string, g models.Game) string {
+	data := fmt.Sprintf("%s-%s-%s-%s-%s", teamKey, g.TeamCaptain, g.Date.Format("2006-01-02"), g.Time, g.Court)
+	hash := md5.Sum([]byte(data))
+	return fmt.Sprintf("%s-%x", prefix, hash)[:len(prefix)+13]
+}
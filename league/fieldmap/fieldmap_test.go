@@ -0,0 +1,191 @@
+package fieldmap
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func record(values map[string]string) Getter {
+	return func(source string) string { return values[source] }
+}
+
+func contains(name string) func(string) bool {
+	return func(s string) bool { return strings.Contains(strings.ToLower(s), strings.ToLower(name)) }
+}
+
+func TestFromAPI(t *testing.T) {
+	m := FromAPI(map[string]string{
+		"team_column":  "Team",
+		"date_column":  "Date",
+		"court_column": " Field ",
+		"url":          "https://example.com",
+	}, "_column")
+
+	assert.Equal(t, map[string]string{Team: "Team", Date: "Date", Court: "Field"}, m.Sources)
+	assert.Equal(t, DefaultDateFormat, m.DateFormat)
+	assert.Empty(t, m.TimeFormat)
+}
+
+func TestAPIKeys(t *testing.T) {
+	required, optional := APIKeys("_path", Team, Date)
+	assert.Equal(t, []string{"team_path", "date_path"}, required)
+	assert.Contains(t, optional, "opponent_path")
+	assert.Contains(t, optional, "date_format")
+}
+
+func TestValidate(t *testing.T) {
+	assert.Empty(t, Mapping{DateFormat: "2006-01-02", TimeFormat: "15:04"}.Validate())
+
+	problems := Mapping{DateFormat: "MM/DD/YYYY", TimeFormat: "hh:mm"}.Validate()
+	require.Len(t, problems, 2)
+	assert.Contains(t, problems[0], "api.date_format")
+	assert.Contains(t, problems[1], "api.time_format")
+}
+
+func TestGame(t *testing.T) {
+	m := Mapping{
+		Sources: map[string]string{
+			Team: "Team", Date: "Date", Time: "Time", Court: "Court", Opponent: "Opponent", Division: "Div",
+		},
+		DateFormat: "2006-01-02",
+		TimeFormat: "3:04 PM",
+	}
+
+	g, ok, err := m.Game(record(map[string]string{
+		"Team": "Dig Dug", "Date": "2026-03-10", "Time": "7:30 pm", "Court": "Court 3", "Opponent": "Net Results", "Div": "B",
+	}), contains("dig dug"))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	assert.Equal(t, "Dig Dug", g.TeamCaptain)
+	assert.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local), g.Date)
+	assert.Equal(t, "7:30 pm", g.Time)
+	assert.Equal(t, "3", g.Court)
+	assert.Equal(t, "Net Results", g.Opponent)
+	assert.Equal(t, "B", g.Division)
+
+	_, ok, err = m.Game(record(map[string]string{"Team": "Someone Else", "Date": "2026-03-10"}), contains("dig dug"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestGameHomeAwayColumns(t *testing.T) {
+	m := Mapping{
+		Sources:    map[string]string{Team: "Home|Away", Date: "Date"},
+		DateFormat: "1/2/2006",
+	}
+
+	g, ok, err := m.Game(record(map[string]string{"Home": "Net Results", "Away": "Dig Dug", "Date": "3/10/2026"}), contains("dig dug"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "Dig Dug", g.TeamCaptain)
+	assert.Equal(t, "Net Results", g.Opponent)
+}
+
+func TestGameWithoutTeamSource(t *testing.T) {
+	m := Mapping{Sources: map[string]string{Date: "Date", Opponent: "Opponent"}, DateFormat: "1/2/2006"}
+
+	g, ok, err := m.Game(record(map[string]string{"Date": "3/10/2026", "Opponent": "Net Results"}), contains("dig dug"))
+	require.NoError(t, err)
+	assert.True(t, ok, "every record belongs to the team")
+	assert.Empty(t, g.TeamCaptain)
+	assert.Equal(t, "Net Results", g.Opponent)
+}
+
+func TestGameDateWithClock(t *testing.T) {
+	m := Mapping{
+		Sources:    map[string]string{Team: "Team", Date: "When"},
+		DateFormat: "01/02/2006 15:04",
+	}
+
+	g, ok, err := m.Game(record(map[string]string{"Team": "Dig Dug", "When": "03/10/2026 19:05"}), contains("dig dug"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "7:05 pm", g.Time)
+	assert.Equal(t, 0, g.Date.Hour())
+}
+
+func TestGameDateWithoutYear(t *testing.T) {
+	m := Mapping{
+		Sources:    map[string]string{Team: "Team", Date: "Date"},
+		DateFormat: "1/2",
+	}
+
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return time.Date(2026, 12, 20, 12, 0, 0, 0, time.UTC) }
+
+	// The year is the one that puts the date nearest today, so January
+	// games listed in December are next year's.
+	g, _, err := m.Game(record(map[string]string{"Team": "Dig Dug", "Date": "1/5"}), contains("dig dug"))
+	require.NoError(t, err)
+	assert.Equal(t, 2027, g.Date.Year())
+	assert.Equal(t, time.January, g.Date.Month())
+
+	g, _, err = m.Game(record(map[string]string{"Team": "Dig Dug", "Date": "3/10"}), contains("dig dug"))
+	require.NoError(t, err)
+	assert.Equal(t, 2027, g.Date.Year())
+
+	g, _, err = m.Game(record(map[string]string{"Team": "Dig Dug", "Date": "11/3"}), contains("dig dug"))
+	require.NoError(t, err)
+	assert.Equal(t, 2026, g.Date.Year())
+}
+
+func TestGameUnixDate(t *testing.T) {
+	m := Mapping{Sources: map[string]string{Team: "Team", Date: "Start"}, DateFormat: UnixDate}
+	start := time.Date(2026, 3, 10, 19, 30, 0, 0, time.Local)
+
+	g, _, err := m.Game(record(map[string]string{"Team": "Dig Dug", "Start": fmt.Sprint(start.Unix())}), contains("dig dug"))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local), g.Date)
+	assert.Equal(t, "7:30 pm", g.Time)
+	assert.Empty(t, m.Validate())
+}
+
+func TestGameLocation(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	require.NoError(t, err)
+	m := Mapping{
+		Sources:    map[string]string{Team: "Team", Date: "Start"},
+		DateFormat: time.RFC3339,
+		Location:   denver,
+	}
+
+	// 01:30 UTC on the 9th is the evening of the 8th in Denver, the day
+	// daylight saving time starts.
+	g, _, err := m.Game(record(map[string]string{"Team": "Dig Dug", "Start": "2026-03-09T01:30:00Z"}), contains("dig dug"))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 8, 0, 0, 0, 0, denver), g.Date)
+	assert.Equal(t, "7:30 pm", g.Time)
+	assert.Equal(t, "America/Denver", g.Timezone)
+	assert.True(t, g.StartsAt.Equal(time.Date(2026, 3, 9, 1, 30, 0, 0, time.UTC)))
+	assert.Equal(t, time.Hour, g.EndsAt.Sub(g.StartsAt))
+}
+
+func TestGameBadDate(t *testing.T) {
+	m := Mapping{Sources: map[string]string{Team: "Team", Date: "Date"}, DateFormat: "2006-01-02"}
+
+	_, ok, err := m.Game(record(map[string]string{"Team": "Dig Dug", "Date": "TBD"}), contains("dig dug"))
+	assert.True(t, ok)
+	assert.Error(t, err)
+}
+
+func TestGameID(t *testing.T) {
+	m := Mapping{Sources: map[string]string{Team: "Team", Date: "Date", Court: "Court"}, DateFormat: "2006-01-02"}
+	row := map[string]string{"Team": "Dig Dug", "Date": "2026-03-10", "Court": "1"}
+
+	g1, _, _ := m.Game(record(row), contains("dig dug"))
+	g2, _, _ := m.Game(record(row), contains("dig dug"))
+	id := GameID("csv", "digdug", g1)
+	assert.Equal(t, id, GameID("csv", "digdug", g2))
+	assert.True(t, strings.HasPrefix(id, "csv-"))
+	assert.Len(t, id, len("csv-")+12)
+
+	row["Court"] = "2"
+	moved, _, _ := m.Game(record(row), contains("dig dug"))
+	assert.NotEqual(t, id, GameID("csv", "digdug", moved))
+}
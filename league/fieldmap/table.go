@@ -0,0 +1,76 @@
+package fieldmap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/models"
+)
+
+// Table is tabular schedule data: a header row and the data rows after it.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// Column returns the index of a column given by header name
+// (case-insensitive) or by 1-based position written as "#3".
+func (t *Table) Column(source string) (int, bool) {
+	if strings.HasPrefix(source, "#") {
+		n, err := strconv.Atoi(source[1:])
+		if err != nil || n < 1 {
+			return 0, false
+		}
+		return n - 1, true
+	}
+	for i, h := range t.Headers {
+		if strings.EqualFold(strings.TrimSpace(h), source) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// CheckColumns reports mapped columns missing from the table, so a renamed
+// sheet column fails loudly instead of silently producing no games.
+func (t *Table) CheckColumns(m Mapping) error {
+	var missing []string
+	for _, f := range Fields {
+		src, ok := m.Sources[f]
+		if !ok {
+			continue
+		}
+		for _, alt := range strings.Split(src, "|") {
+			alt = strings.TrimSpace(alt)
+			if _, ok := t.Column(alt); !ok {
+				missing = append(missing, fmt.Sprintf("%s column %q", f, alt))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing %s (headers: %s)", strings.Join(missing, ", "), strings.Join(t.Headers, ", "))
+	}
+	return nil
+}
+
+// getter reads cells of one row by column source.
+func (t *Table) getter(row []string) Getter {
+	return func(source string) string {
+		i, ok := t.Column(source)
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return row[i]
+	}
+}
+
+// Games maps each row naming the team to a game; see the package-level Games.
+func (t *Table) Games(m Mapping, team league.TeamMatcher, leagueType string) ([]models.Game, []error) {
+	records := make([]Getter, len(t.Rows))
+	for i, row := range t.Rows {
+		records[i] = t.getter(row)
+	}
+	return Games(records, m, team, leagueType)
+}
@@ -0,0 +1,22 @@
+package htmltable
+
+import (
+	"context"
+
+	"github.com/aweist/schedule-watcher/client"
+)
+
+type HTMLClient struct {
+	fetcher *client.Fetcher
+}
+
+func NewClient() *HTMLClient {
+	return &HTMLClient{
+		fetcher: client.Default(),
+	}
+}
+
+// FetchPage downloads a schedule page.
+func (c *HTMLClient) FetchPage(ctx context.Context, url string) (string, error) {
+	return c.fetcher.Get(ctx, url, map[string]string{"Accept": "text/html"})
+}
@@ -0,0 +1,164 @@
+package htmltable
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/league/fieldmap"
+	"github.com/aweist/schedule-watcher/models"
+)
+
+// columnSuffix is appended to a field name to form its api key
+// (e.g. "date_column").
+const columnSuffix = "_column"
+
+func init() {
+	required, optional := fieldmap.APIKeys(columnSuffix, fieldmap.Date)
+	league.Register("html_table", league.Type{
+		Description: "Schedule table scraped from a web page; table picked by header text, columns mapped in config",
+		RequiredAPI: append([]string{"url"}, required...),
+		OptionalAPI: append(optional, "table_header"),
+		Validate: func(cfg config.LeagueConfig) []string {
+			problems := fieldmap.FromAPI(cfg.API, columnSuffix).Validate()
+			if cfg.API["table_header"] == "" && strings.HasPrefix(cfg.API["date_column"], "#") {
+				problems = append(problems, "api.table_header is required when date_column is a position")
+			}
+			if cfg.API["team_column"] == "" && len(cfg.Teams) > 1 {
+				problems = append(problems, "api.team_column is required to tell several teams apart on one page")
+			}
+			return append(problems, league.ValidateMatchPatterns(cfg)...)
+		},
+		Factory: func(name string, cfg config.LeagueConfig) (league.League, error) {
+			lg, err := New(name, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return lg, nil
+		},
+	})
+}
+
+type HTMLTableLeague struct {
+	name         string
+	displayName  string
+	notifyMode   string
+	reminderTime string
+	url          string
+	tableHeader  string
+	mapping      fieldmap.Mapping
+	client       *HTMLClient
+	teams        []league.TeamConfig
+	matchers     []league.TeamMatcher
+	lastRawHTML  string
+}
+
+func New(name string, cfg config.LeagueConfig) (*HTMLTableLeague, error) {
+	url := cfg.API["url"]
+	if url == "" {
+		return nil, fmt.Errorf("api.url is required for HTML table league")
+	}
+
+	mapping := fieldmap.FromAPI(cfg.API, columnSuffix)
+
+	// Without an explicit header to look for, the table is the one with
+	// the date column.
+	tableHeader := cfg.API["table_header"]
+	if tableHeader == "" {
+		tableHeader = mapping.Sources[fieldmap.Date]
+	}
+
+	var teams []league.TeamConfig
+	for _, t := range cfg.Teams {
+		teams = append(teams, league.TeamConfig{
+			Key:  t.Key,
+			Name: t.Name,
+		})
+	}
+
+	matchers, err := league.NewTeamMatchers(cfg.Teams)
+	if err != nil {
+		return nil, err
+	}
+	location, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+	mapping.Location = location
+	mapping.Duration, err = cfg.GameLength(fieldmap.DefaultGameDuration)
+	if err != nil {
+		return nil, fmt.Errorf("game_duration: %w", err)
+	}
+
+	notifyMode := cfg.NotifyMode
+	if notifyMode == "" {
+		notifyMode = league.NotifyImmediate
+	}
+
+	return &HTMLTableLeague{
+		name:         cfg.StorageNamespace(name),
+		displayName:  name,
+		notifyMode:   notifyMode,
+		reminderTime: cfg.ReminderTime,
+		url:          url,
+		tableHeader:  tableHeader,
+		mapping:      mapping,
+		client:       NewClient(),
+		teams:        teams,
+		matchers:     matchers,
+	}, nil
+}
+
+func (l *HTMLTableLeague) Name() string               { return l.name }
+func (l *HTMLTableLeague) DisplayName() string        { return l.displayName }
+func (l *HTMLTableLeague) NotifyMode() string         { return l.notifyMode }
+func (l *HTMLTableLeague) ReminderTime() string       { return l.reminderTime }
+func (l *HTMLTableLeague) Teams() []league.TeamConfig { return l.teams }
+func (l *HTMLTableLeague) LastRawData() string        { return l.lastRawHTML }
+func (l *HTMLTableLeague) Location() *time.Location   { return l.mapping.Location }
+
+// GameTimes implements league.GameTimer.
+func (l *HTMLTableLeague) GameTimes(game models.Game) (time.Time, time.Time, bool) {
+	return l.mapping.GameTimes(game)
+}
+
+func (l *HTMLTableLeague) FetchAndParse(ctx context.Context) (map[string][]models.Game, error) {
+	page, err := l.client.FetchPage(ctx, l.url)
+	if err != nil {
+		return nil, fmt.Errorf("fetching schedule page: %w", err)
+	}
+	l.lastRawHTML = page
+
+	return l.ParseRaw(page)
+}
+
+// ParseRaw implements league.RawParser for a saved copy of the page.
+func (l *HTMLTableLeague) ParseRaw(page string) (map[string][]models.Game, error) {
+	table, err := FindTable(page, l.tableHeader)
+	if err != nil {
+		return nil, fmt.Errorf("finding schedule table: %w", err)
+	}
+	if err := table.CheckColumns(l.mapping); err != nil {
+		return nil, fmt.Errorf("schedule table columns: %w", err)
+	}
+
+	result := make(map[string][]models.Game)
+	for _, m := range l.matchers {
+		games, errs := table.Games(l.mapping, m, "html_table")
+		for _, err := range errs {
+			log.Printf("HTML table: skipping %s row for team %s: %v", l.displayName, m.Entry.Key, err)
+		}
+		for i := range games {
+			games[i].League = l.name
+			games[i].LeagueName = l.displayName
+		}
+		log.Printf("HTML table: found %d games for team %s in %s", len(games), m.Entry.Key, l.displayName)
+		result[m.Entry.Key] = games
+	}
+
+	return result, nil
+}
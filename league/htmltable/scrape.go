@@ -0,0 +1,111 @@
+package htmltable
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/aweist/schedule-watcher/league/fieldmap"
+)
+
+var (
+	// Match opening and closing table tags, to pair them up with nesting.
+	tableTagRe = regexp.MustCompile(`(?i)<(/?)table\b[^>]*>`)
+	// Match table rows: <TR ...> content </TR>
+	trRe = regexp.MustCompile(`(?is)<TR[^>]*>(.*?)</TR>`)
+	// Match table cells: <TD ...> content </TD> or <TH ...> content </TH>
+	tdRe = regexp.MustCompile(`(?is)<T[DH][^>]*>(.*?)</T[DH]>`)
+
+	tagRe   = regexp.MustCompile(`<[^>]*>`)
+	spaceRe = regexp.MustCompile(`\s+`)
+)
+
+// ExtractTables returns the rows of every table in the page as cell text, in
+// document order. Tables nested inside a table are returned separately and
+// left out of the enclosing table's rows.
+func ExtractTables(page string) [][][]string {
+	type span struct{ start, end int }
+	var (
+		open   []int
+		spans  []span
+		tables [][][]string
+	)
+
+	for _, loc := range tableTagRe.FindAllStringSubmatchIndex(page, -1) {
+		closing := loc[3] > loc[2]
+		if !closing {
+			open = append(open, loc[1])
+			continue
+		}
+		if len(open) == 0 {
+			continue
+		}
+		start := open[len(open)-1]
+		open = open[:len(open)-1]
+		spans = append(spans, span{start, loc[0]})
+	}
+
+	// Spans are in closing order; put them back in document order.
+	for i := 1; i < len(spans); i++ {
+		for j := i; j > 0 && spans[j].start < spans[j-1].start; j-- {
+			spans[j], spans[j-1] = spans[j-1], spans[j]
+		}
+	}
+
+	for i, s := range spans {
+		var b strings.Builder
+		pos := s.start
+		for _, inner := range spans[i+1:] {
+			if inner.start >= s.end {
+				break
+			}
+			if inner.start < pos {
+				continue // nested deeper, already skipped
+			}
+			b.WriteString(page[pos:inner.start])
+			pos = inner.end
+		}
+		b.WriteString(page[pos:s.end])
+		tables = append(tables, parseRows(b.String()))
+	}
+	return tables
+}
+
+func parseRows(content string) [][]string {
+	var rows [][]string
+	for _, tr := range trRe.FindAllStringSubmatch(content, -1) {
+		var cells []string
+		for _, td := range tdRe.FindAllStringSubmatch(tr[1], -1) {
+			cells = append(cells, cellText(td[1]))
+		}
+		if len(cells) > 0 {
+			rows = append(rows, cells)
+		}
+	}
+	return rows
+}
+
+// cellText strips tags and decodes entities, collapsing whitespace.
+func cellText(s string) string {
+	s = tagRe.ReplaceAllString(s, " ")
+	s = html.UnescapeString(s)
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	return strings.TrimSpace(spaceRe.ReplaceAllString(s, " "))
+}
+
+// FindTable returns the first table with a row containing a cell equal to
+// header (case-insensitive). That row becomes the table's headers and the
+// rows after it its data.
+func FindTable(page, header string) (*fieldmap.Table, error) {
+	for _, rows := range ExtractTables(page) {
+		for i, row := range rows {
+			for _, cell := range row {
+				if strings.EqualFold(cell, header) {
+					return &fieldmap.Table{Headers: row, Rows: rows[i+1:]}, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("no table with a %q header", header)
+}
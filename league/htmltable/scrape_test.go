@@ -0,0 +1,100 @@
+package htmltable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/league/fieldmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const samplePageHTML = `
+<html><body>
+<table class="layout">
+  <tr><td>
+    <table class="nav"><tr><td>Home</td><td>Leagues</td></tr></table>
+  </td></tr>
+  <tr><td>
+    <h2>Tuesday Coed</h2>
+    <TABLE CELLPADDING=3 WIDTH=700>
+       <TR>
+          <TH>Week</TH>
+          <TH>Game Time</TH>
+          <TH>Court</TH>
+          <TH>Other Team Name</TH>
+       </TR>
+       <TR BGCOLOR=white>
+          <TD ALIGN=CENTER>1</TD>
+          <TD ALIGN=CENTER>03/17/2026 &nbsp;&nbsp; 9:40 PM</TD>
+          <TD ALIGN=CENTER>Court 2</TD>
+          <TD ALIGN=LEFT><a href="/t/2">2 - Pat's Team</a></TD>
+       </TR>
+       <TR BGCOLOR=gainsboro>
+          <TD ALIGN=CENTER>2</TD>
+          <TD ALIGN=CENTER>03/24/2026 &nbsp;&nbsp; 5:10 PM</TD>
+          <TD ALIGN=CENTER>Court 4</TD>
+          <TD ALIGN=LEFT>5 - Papa &amp; Family</TD>
+       </TR>
+    </TABLE>
+  </td></tr>
+</table>
+</body></html>`
+
+func TestExtractTablesNested(t *testing.T) {
+	tables := ExtractTables(samplePageHTML)
+	require.Len(t, tables, 3)
+
+	// The layout table keeps only its own cells.
+	for _, row := range tables[0] {
+		for _, cell := range row {
+			assert.NotContains(t, cell, "Leagues")
+			assert.NotContains(t, cell, "Game Time")
+		}
+	}
+	assert.Equal(t, [][]string{{"Home", "Leagues"}}, tables[1])
+	assert.Equal(t, []string{"Week", "Game Time", "Court", "Other Team Name"}, tables[2][0])
+	assert.Equal(t, "03/17/2026 9:40 PM", tables[2][1][1])
+	assert.Equal(t, "5 - Papa & Family", tables[2][2][3])
+}
+
+func TestFindTable(t *testing.T) {
+	table, err := FindTable(samplePageHTML, "game time")
+	require.NoError(t, err)
+	assert.Equal(t, "Week", table.Headers[0])
+	assert.Len(t, table.Rows, 2)
+
+	_, err = FindTable(samplePageHTML, "Standings")
+	assert.Error(t, err)
+}
+
+func TestSingleTeamPageGames(t *testing.T) {
+	table, err := FindTable(samplePageHTML, "Game Time")
+	require.NoError(t, err)
+
+	mapping := fieldmap.FromAPI(map[string]string{
+		"date_column":     "Game Time",
+		"court_column":    "Court",
+		"opponent_column": "Other Team Name",
+		"date_format":     "01/02/2006 3:04 PM",
+	}, columnSuffix)
+	require.NoError(t, table.CheckColumns(mapping))
+
+	team, err := league.NewTeamMatcher(config.TeamEntry{Key: "ftm", Name: "French Toast Mafia"})
+	require.NoError(t, err)
+
+	games, errs := table.Games(mapping, team, "html_table")
+	require.Empty(t, errs)
+	require.Len(t, games, 2)
+
+	g := games[0]
+	assert.Equal(t, "French Toast Mafia", g.TeamCaptain)
+	assert.Equal(t, "ftm", g.TeamKey)
+	assert.Equal(t, "html_table", g.LeagueType)
+	assert.Equal(t, time.Date(2026, 3, 17, 0, 0, 0, 0, time.Local), g.Date)
+	assert.Equal(t, "9:40 pm", g.Time)
+	assert.Equal(t, "2", g.Court)
+	assert.Equal(t, "2 - Pat's Team", g.Opponent)
+}
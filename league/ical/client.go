@@ -0,0 +1,27 @@
+package ical
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aweist/schedule-watcher/client"
+)
+
+type ICalClient struct {
+	fetcher *client.Fetcher
+}
+
+func NewClient() *ICalClient {
+	return &ICalClient{
+		fetcher: client.Default(),
+	}
+}
+
+// FetchFeed downloads an ICS feed. webcal:// URLs are fetched over HTTPS.
+func (c *ICalClient) FetchFeed(ctx context.Context, url string) (string, error) {
+	if strings.HasPrefix(url, "webcal://") {
+		url = "https://" + strings.TrimPrefix(url, "webcal://")
+	}
+
+	return c.fetcher.Get(ctx, url, map[string]string{"Accept": "text/calendar"})
+}
@@ -0,0 +1,157 @@
+package ical
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/models"
+)
+
+func init() {
+	league.Register("ical", league.Type{
+		Description: "iCalendar (ICS/webcal) feed; each event becomes a game, filtered per team by match pattern",
+		RequiredAPI: []string{"url"},
+		Validate:    league.ValidateMatchPatterns,
+		Factory: func(name string, cfg config.LeagueConfig) (league.League, error) {
+			lg, err := New(name, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return lg, nil
+		},
+	})
+}
+
+// defaultGameDuration is the length of games whose events have no end.
+const defaultGameDuration = time.Hour
+
+type ICalLeague struct {
+	name         string
+	displayName  string
+	notifyMode   string
+	reminderTime string
+	url          string
+	client       *ICalClient
+	teams        []league.TeamConfig
+	matchers     []league.TeamMatcher
+	location     *time.Location
+	gameDuration time.Duration
+	lastRawICS   string
+}
+
+func New(name string, cfg config.LeagueConfig) (*ICalLeague, error) {
+	url := cfg.API["url"]
+	if url == "" {
+		return nil, fmt.Errorf("api.url is required for iCal league")
+	}
+
+	var teams []league.TeamConfig
+	for _, t := range cfg.Teams {
+		teams = append(teams, league.TeamConfig{
+			Key:  t.Key,
+			Name: t.Name,
+		})
+	}
+
+	matchers, err := league.NewTeamMatchers(cfg.Teams)
+	if err != nil {
+		return nil, err
+	}
+	location, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+	gameDuration, err := cfg.GameLength(defaultGameDuration)
+	if err != nil {
+		return nil, fmt.Errorf("game_duration: %w", err)
+	}
+
+	notifyMode := cfg.NotifyMode
+	if notifyMode == "" {
+		notifyMode = league.NotifyImmediate
+	}
+
+	return &ICalLeague{
+		name:         cfg.StorageNamespace(name),
+		displayName:  name,
+		notifyMode:   notifyMode,
+		reminderTime: cfg.ReminderTime,
+		url:          url,
+		client:       NewClient(),
+		teams:        teams,
+		matchers:     matchers,
+		location:     location,
+		gameDuration: gameDuration,
+	}, nil
+}
+
+func (l *ICalLeague) Name() string               { return l.name }
+func (l *ICalLeague) DisplayName() string        { return l.displayName }
+func (l *ICalLeague) NotifyMode() string         { return l.notifyMode }
+func (l *ICalLeague) ReminderTime() string       { return l.reminderTime }
+func (l *ICalLeague) Teams() []league.TeamConfig { return l.teams }
+func (l *ICalLeague) LastRawData() string        { return l.lastRawICS }
+func (l *ICalLeague) Location() *time.Location   { return l.location }
+
+// GameTimes implements league.GameTimer.
+func (l *ICalLeague) GameTimes(game models.Game) (time.Time, time.Time, bool) {
+	start, ok := models.ParseClock(game.Date, game.Time, l.location, false)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(l.gameDuration), true
+}
+
+func (l *ICalLeague) FetchAndParse(ctx context.Context) (map[string][]models.Game, error) {
+	feed, err := l.client.FetchFeed(ctx, l.url)
+	if err != nil {
+		return nil, fmt.Errorf("fetching iCal feed: %w", err)
+	}
+	l.lastRawICS = feed
+
+	return l.ParseRaw(feed)
+}
+
+// ParseRaw implements league.RawParser for a saved feed.
+func (l *ICalLeague) ParseRaw(feed string) (map[string][]models.Game, error) {
+	events, err := ParseEvents(feed, l.location)
+	if err != nil {
+		return nil, fmt.Errorf("parsing iCal feed: %w", err)
+	}
+
+	result := make(map[string][]models.Game)
+	for _, m := range l.matchers {
+		games := gamesForTeam(events, m)
+		for i := range games {
+			games[i].League = l.name
+			games[i].LeagueName = l.displayName
+			games[i].Timezone = l.location.String()
+			if !games[i].StartsAt.IsZero() && games[i].EndsAt.IsZero() {
+				games[i].EndsAt = games[i].StartsAt.Add(l.gameDuration)
+			}
+		}
+		log.Printf("iCal: found %d games for team %s in %s", len(games), m.Entry.Key, l.displayName)
+		result[m.Entry.Key] = games
+	}
+
+	return result, nil
+}
+
+// gamesForTeam converts the team's events to games, skipping cancelled ones.
+func gamesForTeam(events []Event, m league.TeamMatcher) []models.Game {
+	var games []models.Game
+	for _, ev := range events {
+		if ev.Cancelled {
+			continue
+		}
+		if !m.Matches(ev.Summary) && !m.Matches(ev.Description) {
+			continue
+		}
+		games = append(games, eventToGame(ev, m.Entry.Key, m.Entry.Name, m.Matches))
+	}
+	return games
+}
@@ -0,0 +1,239 @@
+package ical
+
+import (
+	"crypto/md5"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aweist/schedule-watcher/models"
+)
+
+// Event is the subset of a VEVENT needed to build a game.
+type Event struct {
+	UID         string
+	Summary     string
+	Location    string
+	Description string
+	Start       time.Time
+	End         time.Time // zero without a DTEND
+	AllDay      bool
+	Cancelled   bool
+}
+
+var (
+	// Splits "Team A vs Team B", "Team A v. Team B" or "Team A @ Team B".
+	versusRe = regexp.MustCompile(`(?i)\s+(?:vs\.?|v\.?|versus|@)\s+`)
+	// Matches a leading "vs Team B" / "@ Team B" in single-team feeds.
+	versusPrefixRe = regexp.MustCompile(`(?i)^(?:vs\.?|v\.?|versus|@)\s+`)
+)
+
+// ParseEvents extracts the VEVENTs from an ICS document. Times without a
+// zone or TZID are interpreted in loc.
+func ParseEvents(ics string, loc *time.Location) ([]Event, error) {
+	lines := unfold(ics)
+	if len(lines) == 0 || !strings.EqualFold(strings.TrimSpace(lines[0]), "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("not an iCalendar feed")
+	}
+
+	var events []Event
+	var cur *Event
+	// depth counts the components open inside the current VEVENT, such as a
+	// VALARM, whose properties aren't the event's.
+	depth := 0
+
+	for _, line := range lines {
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && cur == nil && strings.EqualFold(value, "VEVENT"):
+			cur = &Event{}
+			depth = 0
+			continue
+		case name == "BEGIN" && cur != nil:
+			depth++
+			continue
+		case name == "END" && cur != nil && depth > 0:
+			depth--
+			continue
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if cur != nil && !cur.Start.IsZero() {
+				events = append(events, *cur)
+			}
+			cur = nil
+			continue
+		}
+
+		if cur == nil || depth > 0 {
+			continue
+		}
+
+		switch name {
+		case "UID":
+			cur.UID = value
+		case "SUMMARY":
+			cur.Summary = unescapeText(value)
+		case "LOCATION":
+			cur.Location = unescapeText(value)
+		case "DESCRIPTION":
+			cur.Description = unescapeText(value)
+		case "STATUS":
+			cur.Cancelled = strings.EqualFold(value, "CANCELLED")
+		case "DTSTART":
+			start, allDay, err := parseDateTime(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("event %q: %w", cur.UID, err)
+			}
+			cur.Start = start
+			cur.AllDay = allDay
+		case "DTEND":
+			end, _, err := parseDateTime(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("event %q: %w", cur.UID, err)
+			}
+			cur.End = end
+		}
+	}
+
+	return events, nil
+}
+
+// unfold joins continuation lines (those starting with a space or tab) onto
+// the previous line, per RFC 5545 section 3.1.
+func unfold(ics string) []string {
+	raw := strings.Split(strings.ReplaceAll(ics, "\r\n", "\n"), "\n")
+	var lines []string
+	for _, l := range raw {
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// splitProperty splits "NAME;PARAM=x;PARAM2=\"a:b\":VALUE" into its name,
+// parameters and value. The value starts at the first colon outside quotes.
+func splitProperty(line string) (string, map[string]string, string, bool) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon == -1 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string)
+	for _, p := range parts[1:] {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+func parseDateTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t.In(loc), false, err
+	}
+
+	zone := loc
+	if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			zone = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, zone)
+	return t.In(loc), false, err
+}
+
+func unescapeText(s string) string {
+	r := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return strings.TrimSpace(r.Replace(s))
+}
+
+// opponentFromSummary returns the side of a "A vs B" summary that isn't the
+// team. Summaries that don't name two sides are returned as-is.
+func opponentFromSummary(summary string, isTeam func(string) bool) string {
+	if m := versusPrefixRe.FindStringIndex(summary); m != nil {
+		return strings.TrimSpace(summary[m[1]:])
+	}
+
+	sides := versusRe.Split(summary, 2)
+	if len(sides) != 2 {
+		return summary
+	}
+	if isTeam(sides[0]) {
+		return strings.TrimSpace(sides[1])
+	}
+	if isTeam(sides[1]) {
+		return strings.TrimSpace(sides[0])
+	}
+	return summary
+}
+
+// eventToGame converts an event into a game for the given team.
+func eventToGame(ev Event, teamKey, teamName string, isTeam func(string) bool) models.Game {
+	gameTime := ""
+	var startsAt, endsAt time.Time
+	if !ev.AllDay {
+		gameTime = strings.ToLower(ev.Start.Format("3:04 PM"))
+		startsAt = ev.Start
+		if ev.End.After(ev.Start) {
+			endsAt = ev.End
+		}
+	}
+
+	court := strings.TrimSpace(ev.Location)
+	court = strings.TrimPrefix(court, "Court ")
+	court = strings.TrimPrefix(court, "court ")
+
+	date := time.Date(ev.Start.Year(), ev.Start.Month(), ev.Start.Day(), 0, 0, 0, 0, ev.Start.Location())
+
+	return models.Game{
+		ID:          generateICalGameID(teamKey, ev),
+		LeagueType:  "ical",
+		TeamKey:     teamKey,
+		TeamCaptain: teamName,
+		Date:        date,
+		Time:        gameTime,
+		Court:       court,
+		Opponent:    opponentFromSummary(ev.Summary, isTeam),
+		Raw:         fmt.Sprintf("%s|%s|%s|%s", ev.UID, ev.Start.Format(time.RFC3339), ev.Location, ev.Summary),
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+	}
+}
+
+// generateICalGameID keys games on the event UID and start, so a rescheduled
+// event is treated as a new game just like a changed row in the other sources.
+func generateICalGameID(teamKey string, ev Event) string {
+	uid := ev.UID
+	if uid == "" {
+		uid = ev.Summary
+	}
+	data := fmt.Sprintf("ical-%s-%s-%s", teamKey, uid, ev.Start.UTC().Format(time.RFC3339))
+	hash := md5.Sum([]byte(data))
+	return fmt.Sprintf("ical-%x", hash)[:17]
+}
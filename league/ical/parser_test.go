@@ -0,0 +1,172 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleFeed = strings.ReplaceAll(`BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Rec Center//Leagues//EN
+BEGIN:VEVENT
+UID:evt-1@reccenter
+DTSTART;TZID=America/Chicago:20260310T190000
+DTEND;TZID=America/Chicago:20260310T195000
+SUMMARY:Dig Dug vs Net Results
+LOCATION:Court 2
+END:VEVENT
+BEGIN:VEVENT
+UID:evt-2@reccenter
+DTSTART:20260318T010000Z
+SUMMARY:Spike Lee @ Dig Dug
+LOCATION:Court 4\, North Gym
+DESCRIPTION:Week 2 -
+  bring a light shirt
+END:VEVENT
+BEGIN:VEVENT
+UID:evt-3@reccenter
+DTSTART;TZID=America/Chicago:20260324T200000
+SUMMARY:Dig Dug vs Block Party
+LOCATION:Court 1
+STATUS:CANCELLED
+END:VEVENT
+BEGIN:VEVENT
+UID:evt-4@reccenter
+DTSTART;VALUE=DATE:20260331
+SUMMARY:Block Party vs Spike Lee
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n")
+
+func chicago(t *testing.T) *time.Location {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+	return loc
+}
+
+func TestParseEvents(t *testing.T) {
+	loc := chicago(t)
+	events, err := ParseEvents(sampleFeed, loc)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, "evt-1@reccenter", events[0].UID)
+	assert.Equal(t, time.Date(2026, 3, 10, 19, 0, 0, 0, loc), events[0].Start)
+	assert.Equal(t, time.Date(2026, 3, 10, 19, 50, 0, 0, loc), events[0].End)
+	assert.False(t, events[0].AllDay)
+
+	// UTC times are converted to the league location.
+	assert.Equal(t, time.Date(2026, 3, 17, 20, 0, 0, 0, loc), events[1].Start)
+	assert.Equal(t, "Court 4, North Gym", events[1].Location)
+	assert.Equal(t, "Week 2 - bring a light shirt", events[1].Description)
+
+	assert.True(t, events[2].Cancelled)
+
+	assert.True(t, events[3].AllDay)
+	assert.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, loc), events[3].Start)
+}
+
+func TestParseEventsIgnoresAlarms(t *testing.T) {
+	feed := `BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:evt-1@reccenter
+DTSTART:20260318T010000Z
+BEGIN:VALARM
+ACTION:DISPLAY
+TRIGGER:-PT30M
+SUMMARY:Reminder
+DESCRIPTION:Game in 30 minutes
+END:VALARM
+SUMMARY:Spike Lee @ Dig Dug
+END:VEVENT
+BEGIN:VEVENT
+UID:evt-2@reccenter
+DTSTART:20260325T010000Z
+SUMMARY:Dig Dug vs Block Party
+DESCRIPTION:Week 3
+BEGIN:VALARM
+ACTION:DISPLAY
+DESCRIPTION:Reminder
+END:VALARM
+END:VEVENT
+END:VCALENDAR
+`
+	events, err := ParseEvents(feed, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "Spike Lee @ Dig Dug", events[0].Summary)
+	assert.Empty(t, events[0].Description)
+	assert.Equal(t, "Dig Dug vs Block Party", events[1].Summary)
+	assert.Equal(t, "Week 3", events[1].Description)
+}
+
+func TestParseEventsRejectsNonCalendar(t *testing.T) {
+	_, err := ParseEvents("<html>not found</html>", time.UTC)
+	assert.Error(t, err)
+}
+
+func TestSplitPropertyQuotedParam(t *testing.T) {
+	name, params, value, ok := splitProperty(`DTSTART;TZID="Custom: Zone":20260310T190000`)
+	require.True(t, ok)
+	assert.Equal(t, "DTSTART", name)
+	assert.Equal(t, "Custom: Zone", params["TZID"])
+	assert.Equal(t, "20260310T190000", value)
+}
+
+func TestOpponentFromSummary(t *testing.T) {
+	isTeam := func(s string) bool { return strings.Contains(strings.ToLower(s), "dig dug") }
+
+	assert.Equal(t, "Net Results", opponentFromSummary("Dig Dug vs Net Results", isTeam))
+	assert.Equal(t, "Spike Lee", opponentFromSummary("Spike Lee @ Dig Dug", isTeam))
+	assert.Equal(t, "Net Results", opponentFromSummary("Dig Dug v. Net Results", isTeam))
+	assert.Equal(t, "Net Results", opponentFromSummary("vs Net Results", isTeam))
+	assert.Equal(t, "Playoffs", opponentFromSummary("Playoffs", isTeam))
+}
+
+func TestGamesForTeam(t *testing.T) {
+	loc := chicago(t)
+	events, err := ParseEvents(sampleFeed, loc)
+	require.NoError(t, err)
+
+	m, err := league.NewTeamMatcher(config.TeamEntry{Key: "digdug", Name: "Dig Dug"})
+	require.NoError(t, err)
+	games := gamesForTeam(events, m)
+	require.Len(t, games, 2, "cancelled and other teams' events are skipped")
+
+	g := games[0]
+	assert.Equal(t, "ical", g.LeagueType)
+	assert.Equal(t, "digdug", g.TeamKey)
+	assert.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, loc), g.Date)
+	assert.Equal(t, "7:00 pm", g.Time)
+	assert.Equal(t, time.Date(2026, 3, 10, 19, 0, 0, 0, loc), g.StartsAt)
+	assert.Equal(t, time.Date(2026, 3, 10, 19, 50, 0, 0, loc), g.EndsAt)
+	assert.Equal(t, "2", g.Court)
+	assert.Equal(t, "Net Results", g.Opponent)
+	assert.True(t, strings.HasPrefix(g.ID, "ical-"))
+
+	assert.Equal(t, "4, North Gym", games[1].Court)
+	assert.Equal(t, "Spike Lee", games[1].Opponent)
+	assert.True(t, games[1].EndsAt.IsZero(), "no DTEND; the league fills in its game duration")
+
+	// IDs are stable across parses.
+	again := gamesForTeam(events, m)
+	assert.Equal(t, g.ID, again[0].ID)
+}
+
+func TestGamesForTeamMatchPattern(t *testing.T) {
+	events, err := ParseEvents(sampleFeed, chicago(t))
+	require.NoError(t, err)
+
+	m, err := league.NewTeamMatcher(config.TeamEntry{Key: "bp", Name: "BP", Match: "Block Party"})
+	require.NoError(t, err)
+	games := gamesForTeam(events, m)
+	require.Len(t, games, 1)
+	assert.Equal(t, "", games[0].Time, "all-day events have no time")
+	assert.Equal(t, "Spike Lee", games[0].Opponent)
+}
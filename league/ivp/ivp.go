@@ -1,8 +1,12 @@
 package ivp
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/aweist/schedule-watcher/client"
 	"github.com/aweist/schedule-watcher/config"
@@ -11,6 +15,33 @@ import (
 	"github.com/aweist/schedule-watcher/parser"
 )
 
+// defaultGameDuration is how long an IVP game is booked for.
+const defaultGameDuration = time.Hour
+
+func init() {
+	league.Register("ivp", league.Type{
+		Description: "IVP league table published through the Wix Visual Data API as CSV",
+		RequiredAPI: []string{"instance", "comp_id"},
+		OptionalAPI: []string{"base_url"},
+		Validate: func(cfg config.LeagueConfig) []string {
+			var problems []string
+			for i, t := range cfg.Teams {
+				if _, err := league.NewTeamMatcher(t); err != nil {
+					problems = append(problems, fmt.Sprintf("teams[%d].match: %v", i, err))
+				}
+			}
+			return problems
+		},
+		Factory: func(name string, cfg config.LeagueConfig) (league.League, error) {
+			lg, err := New(name, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return lg, nil
+		},
+	})
+}
+
 type IVPLeague struct {
 	name         string
 	displayName  string
@@ -20,8 +51,15 @@ type IVPLeague struct {
 	instance     string
 	compID       string
 	teams        []league.TeamConfig
-	teamEntries  []config.TeamEntry
+	matchers     []league.TeamMatcher
+	location     *time.Location
+	gameDuration time.Duration
 	lastRawCSV   string
+	standings    []models.Standing
+
+	// warnedAmbiguous holds the keys of teams already reported as matching
+	// several rows, so the warning is logged once rather than every poll.
+	warnedAmbiguous map[string]bool
 }
 
 func New(name string, cfg config.LeagueConfig) (*IVPLeague, error) {
@@ -41,13 +79,26 @@ func New(name string, cfg config.LeagueConfig) (*IVPLeague, error) {
 		})
 	}
 
+	matchers, err := league.NewTeamMatchers(cfg.Teams)
+	if err != nil {
+		return nil, err
+	}
+	location, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+	gameDuration, err := cfg.GameLength(defaultGameDuration)
+	if err != nil {
+		return nil, fmt.Errorf("game_duration: %w", err)
+	}
+
 	notifyMode := cfg.NotifyMode
 	if notifyMode == "" {
 		notifyMode = league.NotifyImmediate
 	}
 
 	return &IVPLeague{
-		name:         "ivp",
+		name:         cfg.StorageNamespace(name),
 		displayName:  name,
 		notifyMode:   notifyMode,
 		reminderTime: cfg.ReminderTime,
@@ -55,39 +106,78 @@ func New(name string, cfg config.LeagueConfig) (*IVPLeague, error) {
 		instance:     instance,
 		compID:       compID,
 		teams:        teams,
-		teamEntries:  cfg.Teams,
+		matchers:     matchers,
+		location:     location,
+		gameDuration: gameDuration,
+
+		warnedAmbiguous: make(map[string]bool),
 	}, nil
 }
 
-func (l *IVPLeague) Name() string               { return l.name }
-func (l *IVPLeague) DisplayName() string        { return l.displayName }
-func (l *IVPLeague) NotifyMode() string         { return l.notifyMode }
-func (l *IVPLeague) ReminderTime() string       { return l.reminderTime }
-func (l *IVPLeague) Teams() []league.TeamConfig { return l.teams }
-func (l *IVPLeague) LastRawData() string        { return l.lastRawCSV }
+func (l *IVPLeague) Name() string                     { return l.name }
+func (l *IVPLeague) DisplayName() string              { return l.displayName }
+func (l *IVPLeague) NotifyMode() string               { return l.notifyMode }
+func (l *IVPLeague) ReminderTime() string             { return l.reminderTime }
+func (l *IVPLeague) Teams() []league.TeamConfig       { return l.teams }
+func (l *IVPLeague) LastRawData() string              { return l.lastRawCSV }
+func (l *IVPLeague) LastStandings() []models.Standing { return l.standings }
+func (l *IVPLeague) Location() *time.Location         { return l.location }
 
-func (l *IVPLeague) FetchAndParse() (map[string][]models.Game, error) {
-	schedule, err := l.apiClient.FetchSchedule(l.instance, l.compID)
+func (l *IVPLeague) FetchAndParse(ctx context.Context) (map[string][]models.Game, error) {
+	schedule, err := l.apiClient.FetchSchedule(ctx, l.instance, l.compID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching IVP schedule: %w", err)
 	}
 	l.lastRawCSV = schedule.CSVData
 
+	return l.ParseRaw(schedule.CSVData)
+}
+
+// ParseRaw implements league.RawParser. raw is the league table as CSV, or
+// the Wix API response that wraps it ({"csvData": "..."}), as saved in
+// docs/csv.
+func (l *IVPLeague) ParseRaw(raw string) (map[string][]models.Game, error) {
+	csvData := raw
+	if strings.HasPrefix(strings.TrimSpace(raw), "{") {
+		var schedule models.Schedule
+		if err := json.Unmarshal([]byte(raw), &schedule); err != nil {
+			return nil, fmt.Errorf("decoding IVP schedule: %w", err)
+		}
+		csvData = schedule.CSVData
+	}
+	l.standings = l.parseStandings(csvData)
+
 	result := make(map[string][]models.Game)
 
-	for _, team := range l.teamEntries {
-		csvParser := parser.NewCSVParser(team.Name)
-		games, err := csvParser.ParseSchedule(schedule.CSVData)
+	warned := false
+	for _, m := range l.matchers {
+		team := m.Entry
+		csvParser := parser.NewCSVParserMatching(m.MatchesTeamInDivision, l.location)
+		games, err := csvParser.ParseSchedule(csvData)
 		if err != nil {
 			log.Printf("Error parsing IVP schedule for team %s: %v", team.Key, err)
 			continue
 		}
+		// The headers are shared by every team, so report them once.
+		if !warned {
+			for _, w := range csvParser.Warnings() {
+				log.Printf("Warning: %s schedule: %s; games on that date skipped", l.name, w)
+			}
+			warned = true
+		}
+		l.warnIfAmbiguous(team, csvParser.MatchedTeams())
 
 		// Tag each game with league and team info, prefix IDs
 		for i := range games {
 			games[i].League = l.name
+			games[i].LeagueName = l.displayName
+			games[i].LeagueType = "ivp"
 			games[i].TeamKey = team.Key
+			games[i].Timezone = l.location.String()
 			games[i].ID = fmt.Sprintf("ivp-%s", games[i].ID)
+			if !games[i].StartsAt.IsZero() {
+				games[i].EndsAt = games[i].StartsAt.Add(l.gameDuration)
+			}
 		}
 
 		result[team.Key] = games
@@ -95,3 +185,73 @@ func (l *IVPLeague) FetchAndParse() (map[string][]models.Game, error) {
 
 	return result, nil
 }
+
+// GameTimes implements league.GameTimer.
+func (l *IVPLeague) GameTimes(game models.Game) (time.Time, time.Time, bool) {
+	start, ok := parser.GameStart(game.Date, game.Time, l.location)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(l.gameDuration), true
+}
+
+// warnIfAmbiguous logs, once per team, when a team entry picks out more
+// than one row of the schedule, since its games then include another team's.
+func (l *IVPLeague) warnIfAmbiguous(team config.TeamEntry, matched []parser.Team) {
+	if len(matched) < 2 || l.warnedAmbiguous[team.Key] {
+		return
+	}
+	l.warnedAmbiguous[team.Key] = true
+
+	rows := make([]string, len(matched))
+	for i, t := range matched {
+		rows[i] = fmt.Sprintf("%q (#%d, %s)", t.Captain, t.Number, t.Division)
+	}
+	log.Printf("Warning: %s team %s matches %d rows: %s; set match_mode: exact, or match_mode: number with team_number and division",
+		l.displayName, team.Key, len(matched), strings.Join(rows, ", "))
+}
+
+// Discover implements league.Discoverer. An IVP league table is a single
+// season, listing each team by captain.
+func (l *IVPLeague) Discover(ctx context.Context, allSeasons bool) ([]league.DiscoveredSeason, error) {
+	schedule, err := l.apiClient.FetchSchedule(ctx, l.instance, l.compID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching IVP schedule: %w", err)
+	}
+
+	teams, err := parser.ParseTeams(schedule.CSVData)
+	if err != nil {
+		return nil, fmt.Errorf("parsing IVP teams: %w", err)
+	}
+
+	season := league.DiscoveredSeason{Name: l.displayName}
+	for _, t := range teams {
+		season.Teams = append(season.Teams, league.DiscoveredTeam{
+			Name:     t.Captain,
+			Number:   t.Number,
+			Division: t.Division,
+		})
+	}
+	return []league.DiscoveredSeason{season}, nil
+}
+
+// parseStandings reads the standings columns, tagging the rows of tracked
+// teams with their keys. Standings are a bonus, so a failure is only logged.
+func (l *IVPLeague) parseStandings(csvData string) []models.Standing {
+	standings, err := parser.ParseStandings(csvData)
+	if err != nil {
+		log.Printf("Error parsing IVP standings for %s: %v", l.displayName, err)
+		return nil
+	}
+
+	for i := range standings {
+		standings[i].League = l.name
+		for _, m := range l.matchers {
+			if m.MatchesTeamInDivision(standings[i].TeamName, standings[i].TeamNumber, standings[i].Division) {
+				standings[i].TeamKey = m.Entry.Key
+				break
+			}
+		}
+	}
+	return standings
+}
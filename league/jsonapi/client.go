@@ -0,0 +1,41 @@
+package jsonapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/aweist/schedule-watcher/client"
+)
+
+type JSONClient struct {
+	fetcher *client.Fetcher
+}
+
+func NewClient() *JSONClient {
+	return &JSONClient{
+		fetcher: client.Default(),
+	}
+}
+
+// FetchJSON GETs rawURL with the given query parameters added and headers
+// set, returning the body.
+func (c *JSONClient) FetchJSON(ctx context.Context, rawURL string, query, headers map[string]string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing URL: %w", err)
+	}
+	if len(query) > 0 {
+		q := u.Query()
+		for k, v := range query {
+			q.Set(k, v)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	h := map[string]string{"Accept": "application/json"}
+	for k, v := range headers {
+		h[k] = v
+	}
+	return c.fetcher.Get(ctx, u.String(), h)
+}
@@ -0,0 +1,187 @@
+package jsonapi
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/league/fieldmap"
+	"github.com/aweist/schedule-watcher/models"
+)
+
+const (
+	// pathSuffix is appended to a field name to form its api key
+	// (e.g. "date_path").
+	pathSuffix = "_path"
+
+	// Api keys with these prefixes become request headers and query
+	// parameters, e.g. "header.Authorization" or "query.season".
+	headerPrefix = "header."
+	queryPrefix  = "query."
+)
+
+func init() {
+	required, optional := fieldmap.APIKeys(pathSuffix, fieldmap.Team, fieldmap.Date)
+	league.Register("json", league.Type{
+		Description: "JSON schedule endpoint; games array and fields picked with JSONPath-like paths in config",
+		RequiredAPI: append([]string{"url"}, required...),
+		OptionalAPI: append(optional, "games_path", headerPrefix+"<name>", queryPrefix+"<name>"),
+		Validate: func(cfg config.LeagueConfig) []string {
+			problems := fieldmap.FromAPI(cfg.API, pathSuffix).Validate()
+			keys := make([]string, 0, len(cfg.API))
+			for key := range cfg.API {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				if key == "games_path" || strings.HasSuffix(key, pathSuffix) {
+					for _, alt := range strings.Split(cfg.API[key], "|") {
+						if _, err := parsePath(alt); err != nil {
+							problems = append(problems, fmt.Sprintf("api.%s: %v", key, err))
+						}
+					}
+				}
+			}
+			return append(problems, league.ValidateMatchPatterns(cfg)...)
+		},
+		Factory: func(name string, cfg config.LeagueConfig) (league.League, error) {
+			lg, err := New(name, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return lg, nil
+		},
+	})
+}
+
+type JSONLeague struct {
+	name         string
+	displayName  string
+	notifyMode   string
+	reminderTime string
+	url          string
+	gamesPath    string
+	headers      map[string]string
+	query        map[string]string
+	mapping      fieldmap.Mapping
+	client       *JSONClient
+	teams        []league.TeamConfig
+	matchers     []league.TeamMatcher
+	lastRawJSON  string
+}
+
+func New(name string, cfg config.LeagueConfig) (*JSONLeague, error) {
+	url := cfg.API["url"]
+	if url == "" {
+		return nil, fmt.Errorf("api.url is required for JSON league")
+	}
+
+	// Header and query values may reference environment variables
+	// ("Bearer ${LEAGUE_TOKEN}") so tokens stay out of the config file.
+	headers := make(map[string]string)
+	query := make(map[string]string)
+	for key, v := range cfg.API {
+		switch {
+		case strings.HasPrefix(key, headerPrefix):
+			headers[strings.TrimPrefix(key, headerPrefix)] = os.ExpandEnv(v)
+		case strings.HasPrefix(key, queryPrefix):
+			query[strings.TrimPrefix(key, queryPrefix)] = os.ExpandEnv(v)
+		}
+	}
+
+	var teams []league.TeamConfig
+	for _, t := range cfg.Teams {
+		teams = append(teams, league.TeamConfig{
+			Key:  t.Key,
+			Name: t.Name,
+		})
+	}
+
+	matchers, err := league.NewTeamMatchers(cfg.Teams)
+	if err != nil {
+		return nil, err
+	}
+	location, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+	mapping := fieldmap.FromAPI(cfg.API, pathSuffix)
+	mapping.Location = location
+	mapping.Duration, err = cfg.GameLength(fieldmap.DefaultGameDuration)
+	if err != nil {
+		return nil, fmt.Errorf("game_duration: %w", err)
+	}
+
+	notifyMode := cfg.NotifyMode
+	if notifyMode == "" {
+		notifyMode = league.NotifyImmediate
+	}
+
+	return &JSONLeague{
+		name:         cfg.StorageNamespace(name),
+		displayName:  name,
+		notifyMode:   notifyMode,
+		reminderTime: cfg.ReminderTime,
+		url:          url,
+		gamesPath:    cfg.API["games_path"],
+		headers:      headers,
+		query:        query,
+		mapping:      mapping,
+		client:       NewClient(),
+		teams:        teams,
+		matchers:     matchers,
+	}, nil
+}
+
+func (l *JSONLeague) Name() string               { return l.name }
+func (l *JSONLeague) DisplayName() string        { return l.displayName }
+func (l *JSONLeague) NotifyMode() string         { return l.notifyMode }
+func (l *JSONLeague) ReminderTime() string       { return l.reminderTime }
+func (l *JSONLeague) Teams() []league.TeamConfig { return l.teams }
+func (l *JSONLeague) LastRawData() string        { return l.lastRawJSON }
+func (l *JSONLeague) Location() *time.Location   { return l.mapping.Location }
+
+// GameTimes implements league.GameTimer.
+func (l *JSONLeague) GameTimes(game models.Game) (time.Time, time.Time, bool) {
+	return l.mapping.GameTimes(game)
+}
+
+func (l *JSONLeague) FetchAndParse(ctx context.Context) (map[string][]models.Game, error) {
+	body, err := l.client.FetchJSON(ctx, l.url, l.query, l.headers)
+	if err != nil {
+		return nil, fmt.Errorf("fetching JSON schedule: %w", err)
+	}
+	l.lastRawJSON = body
+
+	return l.ParseRaw(body)
+}
+
+// ParseRaw implements league.RawParser for a saved response body.
+func (l *JSONLeague) ParseRaw(body string) (map[string][]models.Game, error) {
+	records, err := Records(body, l.gamesPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JSON schedule: %w", err)
+	}
+
+	result := make(map[string][]models.Game)
+	for _, m := range l.matchers {
+		games, errs := fieldmap.Games(records, l.mapping, m, "json")
+		for _, err := range errs {
+			log.Printf("JSON: skipping %s game for team %s: %v", l.displayName, m.Entry.Key, err)
+		}
+		for i := range games {
+			games[i].League = l.name
+			games[i].LeagueName = l.displayName
+		}
+		log.Printf("JSON: found %d games for team %s in %s", len(games), m.Entry.Key, l.displayName)
+		result[m.Entry.Key] = games
+	}
+
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package jsonapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aweist/schedule-watcher/league/fieldmap"
+)
+
+// Records decodes a JSON document and returns a getter for each game
+// selected by gamesPath. A path selecting a single array yields its
+// elements; an empty path expects the document itself to be the array.
+// Field sources are paths relative to each game.
+func Records(body, gamesPath string) ([]fieldmap.Getter, error) {
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding JSON: %w", err)
+	}
+
+	selected, err := Eval(doc, gamesPath)
+	if err != nil {
+		return nil, fmt.Errorf("games path: %w", err)
+	}
+	if len(selected) == 1 {
+		if arr, ok := selected[0].([]any); ok {
+			selected = arr
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("games path %q selected nothing", gamesPath)
+	}
+
+	records := make([]fieldmap.Getter, len(selected))
+	for i, game := range selected {
+		records[i] = getter(game)
+	}
+	return records, nil
+}
+
+func getter(game any) fieldmap.Getter {
+	return func(path string) string {
+		values, err := Eval(game, path)
+		if err != nil || len(values) == 0 {
+			return ""
+		}
+		return valueString(values[0])
+	}
+}
@@ -0,0 +1,121 @@
+package jsonapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// step is one segment of a path: an object key, an array index, or every
+// element of an array.
+type step struct {
+	key   string
+	index int
+	all   bool
+	isKey bool
+}
+
+// parsePath parses a JSONPath-like expression: keys separated by dots, with
+// [n] to index arrays and [*] to take every element, e.g. "$.data.games[*]"
+// or "teams[0].name". The leading "$" is optional.
+func parsePath(path string) ([]step, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+	path = strings.TrimPrefix(path, ".")
+
+	var steps []step
+	for _, seg := range strings.Split(path, ".") {
+		if seg == "" {
+			if path == "" {
+				break
+			}
+			return nil, fmt.Errorf("path %q: empty segment", path)
+		}
+
+		key := seg
+		rest := ""
+		if i := strings.IndexByte(seg, '['); i >= 0 {
+			key, rest = seg[:i], seg[i:]
+		}
+		if key != "" {
+			steps = append(steps, step{key: key, isKey: true})
+		}
+
+		for rest != "" {
+			end := strings.IndexByte(rest, ']')
+			if rest[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("path %q: malformed index in %q", path, seg)
+			}
+			idx := rest[1:end]
+			rest = rest[end+1:]
+			if idx == "*" {
+				steps = append(steps, step{all: true})
+				continue
+			}
+			n, err := strconv.Atoi(idx)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("path %q: bad index %q", path, idx)
+			}
+			steps = append(steps, step{index: n})
+		}
+	}
+	return steps, nil
+}
+
+// Eval returns the values path selects from a decoded JSON document. Missing
+// keys and out-of-range indexes select nothing rather than failing, since
+// optional fields are routinely absent from some records.
+func Eval(doc any, path string) ([]any, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := []any{doc}
+	for _, s := range steps {
+		var next []any
+		for _, v := range current {
+			switch {
+			case s.isKey:
+				if obj, ok := v.(map[string]any); ok {
+					if child, ok := obj[s.key]; ok {
+						next = append(next, child)
+					}
+				}
+			case s.all:
+				if arr, ok := v.([]any); ok {
+					next = append(next, arr...)
+				}
+			default:
+				if arr, ok := v.([]any); ok && s.index < len(arr) {
+					next = append(next, arr[s.index])
+				}
+			}
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// valueString renders a JSON value as a field value. Numbers keep their
+// original text (the document is decoded with UseNumber), so IDs and Unix
+// timestamps aren't mangled into floats.
+func valueString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(b)
+	}
+}
@@ -0,0 +1,110 @@
+package jsonapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/league/fieldmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleResponse = `{
+  "season": {"name": "Spring 2026"},
+  "data": {
+    "games": [
+      {"id": 1001, "start": "2026-03-10T19:00:00-05:00", "venue": {"court": "Court 1"},
+       "home": {"name": "Dig Dug"}, "away": {"name": "Net Results"}, "division": "Rec B"},
+      {"id": 1002, "start": "2026-03-10T20:00:00-05:00", "venue": {"court": "Court 2"},
+       "home": {"name": "Spike Lee"}, "away": {"name": "Block Party"}, "division": "Rec B"},
+      {"id": 1003, "start": "2026-03-17T19:00:00-05:00", "venue": null,
+       "home": {"name": "Block Party"}, "away": {"name": "Dig Dug"}, "division": "Rec B"}
+    ]
+  }
+}`
+
+func decode(t *testing.T, s string) any {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var doc any
+	require.NoError(t, dec.Decode(&doc))
+	return doc
+}
+
+func TestEval(t *testing.T) {
+	doc := decode(t, sampleResponse)
+
+	v, err := Eval(doc, "$.season.name")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"Spring 2026"}, v)
+
+	v, err = Eval(doc, "data.games[1].home.name")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"Spike Lee"}, v)
+
+	v, err = Eval(doc, "data.games[*].id")
+	require.NoError(t, err)
+	assert.Len(t, v, 3)
+	assert.Equal(t, "1003", valueString(v[2]))
+
+	v, err = Eval(doc, "data.games[2].venue.court")
+	require.NoError(t, err)
+	assert.Empty(t, v, "missing values select nothing")
+
+	v, err = Eval(decode(t, `[[1, 2], [3]]`), "[1][0]")
+	require.NoError(t, err)
+	assert.Equal(t, "3", valueString(v[0]))
+}
+
+func TestParsePathErrors(t *testing.T) {
+	for _, p := range []string{"data..games", "games[x]", "games[1", "games[-1]"} {
+		_, err := parsePath(p)
+		assert.Error(t, err, p)
+	}
+}
+
+func TestRecordsGames(t *testing.T) {
+	records, err := Records(sampleResponse, "data.games")
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	// [*] selects the same games.
+	starred, err := Records(sampleResponse, "$.data.games[*]")
+	require.NoError(t, err)
+	assert.Len(t, starred, 3)
+
+	_, err = Records(sampleResponse, "data.matches")
+	assert.Error(t, err)
+
+	mapping := fieldmap.FromAPI(map[string]string{
+		"team_path":     "home.name|away.name",
+		"date_path":     "start",
+		"court_path":    "venue.court",
+		"division_path": "division",
+		"date_format":   time.RFC3339,
+	}, pathSuffix)
+	assert.Empty(t, mapping.Validate())
+
+	team, err := league.NewTeamMatcher(config.TeamEntry{Key: "digdug", Name: "Dig Dug"})
+	require.NoError(t, err)
+
+	games, errs := fieldmap.Games(records, mapping, team, "json")
+	require.Empty(t, errs)
+	require.Len(t, games, 2)
+
+	start := time.Date(2026, 3, 10, 19, 0, 0, 0, time.FixedZone("", -5*3600)).In(time.Local)
+	g := games[0]
+	assert.Equal(t, "json", g.LeagueType)
+	assert.Equal(t, time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local), g.Date)
+	assert.Equal(t, start.Format("3:04 pm"), g.Time)
+	assert.Equal(t, "1", g.Court)
+	assert.Equal(t, "Net Results", g.Opponent)
+	assert.Equal(t, "Rec B", g.Division)
+
+	assert.Equal(t, "Block Party", games[1].Opponent)
+	assert.Empty(t, games[1].Court)
+}
@@ -1,6 +1,11 @@
 package league
 
-import "github.com/aweist/schedule-watcher/models"
+import (
+	"context"
+	"time"
+
+	"github.com/aweist/schedule-watcher/models"
+)
 
 const (
 	NotifyImmediate     = "immediate"
@@ -9,10 +14,11 @@ const (
 
 // League defines the contract that any schedule source must fulfill.
 type League interface {
-	// Name returns a stable identifier for this league (e.g., "ivp", "pins").
+	// Name returns the league's storage namespace, a stable identifier that
+	// is unique per configured league (e.g., "ivp", "ivp-thursday").
 	Name() string
 
-	// DisplayName returns a human-readable name (e.g., "IVP", "PINS").
+	// DisplayName returns a human-readable name (e.g., "IVP", "IVP Thursday").
 	DisplayName() string
 
 	// NotifyMode returns how this league should send notifications:
@@ -24,12 +30,24 @@ type League interface {
 
 	// FetchAndParse fetches schedule data and parses it into games
 	// for all configured teams in this league. Returns a map of team key -> games.
-	FetchAndParse() (map[string][]models.Game, error)
+	// Cancelling ctx abandons the fetch. When only some teams could be
+	// fetched, it returns theirs with a *PartialError.
+	FetchAndParse(ctx context.Context) (map[string][]models.Game, error)
 
 	// Teams returns the list of teams this league is tracking.
 	Teams() []TeamConfig
 }
 
+// PartialError is returned by FetchAndParse alongside the games of the teams
+// that could be fetched when others couldn't. The poller saves those games
+// but counts the poll as failed.
+type PartialError struct {
+	Err error
+}
+
+func (e *PartialError) Error() string { return "some teams failed: " + e.Err.Error() }
+func (e *PartialError) Unwrap() error { return e.Err }
+
 // TeamConfig holds per-team configuration within a league.
 type TeamConfig struct {
 	Key  string // Stable identifier
@@ -43,3 +61,54 @@ type TeamConfig struct {
 type RawDataProvider interface {
 	LastRawData() string
 }
+
+// RawParser is optionally implemented by leagues that can parse an upstream
+// payload they didn't fetch themselves: the document LastRawData returns, or
+// the same response saved to a file. ParseRaw parses it as FetchAndParse
+// would, so stored snapshots and fixtures can be replayed offline.
+type RawParser interface {
+	ParseRaw(raw string) (map[string][]models.Game, error)
+}
+
+// StandingsProvider is optionally implemented by leagues whose source also
+// publishes standings. LastStandings returns the standings parsed by the most
+// recent FetchAndParse, or nil if none were available.
+type StandingsProvider interface {
+	LastStandings() []models.Standing
+}
+
+// ResultReporter is optionally implemented by leagues whose source posts game
+// results (models.Game.Result). NotifyResults reports whether teams should be
+// emailed when a stored game's result is posted.
+type ResultReporter interface {
+	NotifyResults() bool
+}
+
+// SeasonProvider is optionally implemented by leagues that can see a team's
+// next season before it starts. UpcomingSeasons returns the seasons found by
+// the most recent FetchAndParse; their games are also included in its result.
+type SeasonProvider interface {
+	UpcomingSeasons() []models.Season
+}
+
+// Localized is optionally implemented by leagues configured with a time zone.
+// Location returns the zone game dates and reminder times are in.
+type Localized interface {
+	Location() *time.Location
+}
+
+// Location returns lg's time zone, or time.Local if it doesn't have one.
+func Location(lg League) *time.Location {
+	if l, ok := lg.(Localized); ok && l.Location() != nil {
+		return l.Location()
+	}
+	return time.Local
+}
+
+// GameTimer is optionally implemented by leagues whose sources need their
+// own rules to turn a game's Time text into StartsAt and EndsAt (a missing
+// AM/PM, a set game length). GameTimes applies them to a stored game, to fill
+// in games saved before those fields existed.
+type GameTimer interface {
+	GameTimes(game models.Game) (start, end time.Time, ok bool)
+}
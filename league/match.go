@@ -0,0 +1,128 @@
+package league
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aweist/schedule-watcher/config"
+)
+
+// Team match modes (config.TeamEntry.MatchMode).
+const (
+	MatchSubstring = "substring"
+	MatchExact     = "exact"
+	MatchNumber    = "number"
+)
+
+// TeamMatcher decides whether a piece of text (a row's team cell, an event
+// summary) refers to a configured team. It is used by sources that read a
+// shared schedule containing every team in the league.
+type TeamMatcher struct {
+	Entry config.TeamEntry
+	re    *regexp.Regexp
+}
+
+// NewTeamMatcher matches on the team's match pattern, case-insensitively,
+// when one is configured, and otherwise on the team name according to its
+// match mode: as a case-insensitive substring by default, the whole name
+// ignoring case for "exact", or the team number for "number".
+func NewTeamMatcher(t config.TeamEntry) (TeamMatcher, error) {
+	m := TeamMatcher{Entry: t}
+	if t.Match != "" {
+		re, err := regexp.Compile("(?i)" + t.Match)
+		if err != nil {
+			return TeamMatcher{}, fmt.Errorf("team %s: invalid match pattern: %w", t.Key, err)
+		}
+		m.re = re
+	}
+	return m, nil
+}
+
+// Matches reports whether s refers to the team. Sources that know the team
+// number of s should use MatchesTeam instead.
+func (m TeamMatcher) Matches(s string) bool {
+	return m.MatchesTeam(s, 0)
+}
+
+// MatchesTeam reports whether a team listed by name and number (0 if
+// unknown) is this team.
+func (m TeamMatcher) MatchesTeam(name string, number int) bool {
+	if m.re != nil {
+		return m.re.MatchString(name)
+	}
+	switch m.Entry.MatchMode {
+	case MatchExact:
+		return strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(m.Entry.Name))
+	case MatchNumber:
+		return number != 0 && number == m.Entry.TeamNumber
+	default:
+		return strings.Contains(strings.ToLower(name), strings.ToLower(m.Entry.Name))
+	}
+}
+
+// MatchesTeamInDivision is MatchesTeam for sources that also list each
+// team's division. A team with a configured division only matches in it.
+func (m TeamMatcher) MatchesTeamInDivision(name string, number int, division string) bool {
+	if m.Entry.Division != "" && !strings.EqualFold(strings.TrimSpace(division), strings.TrimSpace(m.Entry.Division)) {
+		return false
+	}
+	return m.MatchesTeam(name, number)
+}
+
+// NewTeamMatchers builds a matcher for each team, in config order.
+func NewTeamMatchers(teams []config.TeamEntry) ([]TeamMatcher, error) {
+	var matchers []TeamMatcher
+	for _, t := range teams {
+		m, err := NewTeamMatcher(t)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+// ValidateMatchPatterns reports teams whose match pattern does not compile,
+// or that match by number or division on a source without them. Types that
+// use TeamMatcher can call it from their Validate hook.
+func ValidateMatchPatterns(cfg config.LeagueConfig) []string {
+	var problems []string
+	for i, t := range cfg.Teams {
+		if t.MatchMode == MatchNumber {
+			problems = append(problems, fmt.Sprintf("teams[%d].match_mode: %q is not supported for %s leagues", i, MatchNumber, cfg.Type))
+		}
+		if t.Division != "" {
+			problems = append(problems, fmt.Sprintf("teams[%d].division is not supported for %s leagues", i, cfg.Type))
+		}
+		if t.Match == "" {
+			continue
+		}
+		if _, err := regexp.Compile(t.Match); err != nil {
+			problems = append(problems, fmt.Sprintf("teams[%d].match: %v", i, err))
+		}
+	}
+	return problems
+}
+
+// validateMatchModes reports unknown match modes and settings that go
+// together with them. It applies to every league type.
+func validateMatchModes(cfg config.LeagueConfig) []string {
+	var problems []string
+	for i, t := range cfg.Teams {
+		switch t.MatchMode {
+		case "", MatchSubstring, MatchExact:
+		case MatchNumber:
+			if t.TeamNumber <= 0 {
+				problems = append(problems, fmt.Sprintf("teams[%d].team_number is required when match_mode is %q", i, MatchNumber))
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("teams[%d].match_mode: %q is not one of %q, %q, %q", i, t.MatchMode, MatchSubstring, MatchExact, MatchNumber))
+			continue
+		}
+		if t.Match != "" && t.MatchMode != "" {
+			problems = append(problems, fmt.Sprintf("teams[%d]: match and match_mode can't both be set", i))
+		}
+	}
+	return problems
+}
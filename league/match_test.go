@@ -0,0 +1,66 @@
+package league
+
+import (
+	"testing"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamMatcher_MatchesTeam(t *testing.T) {
+	tests := []struct {
+		name   string
+		entry  config.TeamEntry
+		team   string
+		number int
+		want   bool
+	}{
+		{"substring", config.TeamEntry{Name: "pat's team"}, "2 - Pat's Team 2", 2, true},
+		{"exact", config.TeamEntry{Name: "Pat's Team", MatchMode: MatchExact}, " pat's team ", 0, true},
+		{"exact rejects longer name", config.TeamEntry{Name: "Pat's Team", MatchMode: MatchExact}, "Pat's Team 2", 0, false},
+		{"number", config.TeamEntry{Name: "Pat", MatchMode: MatchNumber, TeamNumber: 12}, "Someone Else", 12, true},
+		{"number mismatch", config.TeamEntry{Name: "Pat", MatchMode: MatchNumber, TeamNumber: 12}, "Pat", 2, false},
+		{"number unknown", config.TeamEntry{Name: "Pat", MatchMode: MatchNumber, TeamNumber: 12}, "Pat", 0, false},
+		{"pattern", config.TeamEntry{Name: "Pat", Match: `^pat's team$`}, "Pat's Team", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewTeamMatcher(tt.entry)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, m.MatchesTeam(tt.team, tt.number))
+		})
+	}
+}
+
+func TestTeamMatcher_MatchesTeamInDivision(t *testing.T) {
+	m, err := NewTeamMatcher(config.TeamEntry{Name: "Alex Lugo", MatchMode: MatchNumber, TeamNumber: 2, Division: "Comp Div 1 AG"})
+	require.NoError(t, err)
+
+	assert.True(t, m.MatchesTeamInDivision("Alex Lugo # 2", 2, " comp div 1 ag"))
+	assert.False(t, m.MatchesTeamInDivision("Alex Lugo # 2", 2, "Rec Div 2"))
+	assert.False(t, m.MatchesTeamInDivision("Alex Lugo #1", 1, "Comp Div 1 AG"))
+
+	// Without a configured division, any division matches.
+	m, err = NewTeamMatcher(config.TeamEntry{Name: "Alex Lugo"})
+	require.NoError(t, err)
+	assert.True(t, m.MatchesTeamInDivision("Alex Lugo #1", 1, "Rec Div 2"))
+}
+
+func TestValidateMatchPatterns(t *testing.T) {
+	cfg := config.LeagueConfig{
+		Type: "ical",
+		Teams: []config.TeamEntry{
+			{Key: "a", Name: "A", Match: "("},
+			{Key: "b", Name: "B", MatchMode: MatchNumber, TeamNumber: 3},
+			{Key: "c", Name: "C", Division: "Rec"},
+		},
+	}
+
+	problems := ValidateMatchPatterns(cfg)
+	require.Len(t, problems, 3)
+	assert.Contains(t, problems[0], "teams[0].match:")
+	assert.Equal(t, `teams[1].match_mode: "number" is not supported for ical leagues`, problems[1])
+	assert.Equal(t, "teams[2].division is not supported for ical leagues", problems[2])
+}
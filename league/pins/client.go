@@ -1,64 +1,87 @@
 package pins
 
 import (
+	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"net/url"
+	"sync"
 	"time"
+
+	"github.com/aweist/schedule-watcher/client"
 )
 
+// DefaultRequestInterval is the least time between requests to a PINS site
+// unless api.request_interval says otherwise. Its CGI is slow, and a poll
+// of several teams asks it for a few pages each.
+const DefaultRequestInterval = time.Second
+
 type PINSClient struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL string
+	fetcher *client.Fetcher
+	limiter *client.Limiter
+
+	// pages holds the pages fetched during the current poll, by URL, so
+	// teams that share a season's pages fetch them once.
+	mu    sync.Mutex
+	pages map[string]string
 }
 
-func NewClient(baseURL string) *PINSClient {
+// NewClient returns a client for the PINS site at baseURL that asks it for
+// at most one page per interval. Clients of the same site share the pace,
+// at the longest interval any of them asks for.
+func NewClient(baseURL string, interval time.Duration) *PINSClient {
+	host := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
 	return &PINSClient{
 		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		fetcher: client.Default(),
+		limiter: client.HostLimiter(host, interval),
+		pages:   make(map[string]string),
 	}
 }
 
+// StartPoll forgets the pages fetched so far, so the next requests see the
+// site's current pages. Call it at the start of each poll.
+func (c *PINSClient) StartPoll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pages = make(map[string]string)
+}
+
 // FetchSchedulesPage fetches the main schedules page to discover available seasons.
-func (c *PINSClient) FetchSchedulesPage() (string, error) {
-	return c.fetch(fmt.Sprintf("%s/schedules.cgi", c.baseURL))
+func (c *PINSClient) FetchSchedulesPage(ctx context.Context) (string, error) {
+	return c.fetch(ctx, fmt.Sprintf("%s/schedules.cgi", c.baseURL))
 }
 
 // FetchTeamsPage fetches the schedule page with a selected season to discover teams.
-func (c *PINSClient) FetchTeamsPage(scheduleID string) (string, error) {
-	return c.fetch(fmt.Sprintf("%s/schedules.cgi?SCHEDULE_ID=%s", c.baseURL, scheduleID))
+func (c *PINSClient) FetchTeamsPage(ctx context.Context, scheduleID string) (string, error) {
+	return c.fetch(ctx, fmt.Sprintf("%s/schedules.cgi?SCHEDULE_ID=%s", c.baseURL, scheduleID))
 }
 
 // FetchTeamSchedule fetches the full schedule page for a specific team.
-func (c *PINSClient) FetchTeamSchedule(scheduleID, teamID string) (string, error) {
-	return c.fetch(fmt.Sprintf("%s/schedules.cgi?SCHEDULE_ID=%s&TEAM_ID=%s", c.baseURL, scheduleID, teamID))
+func (c *PINSClient) FetchTeamSchedule(ctx context.Context, scheduleID, teamID string) (string, error) {
+	return c.fetch(ctx, fmt.Sprintf("%s/schedules.cgi?SCHEDULE_ID=%s&TEAM_ID=%s", c.baseURL, scheduleID, teamID))
 }
 
-func (c *PINSClient) fetch(url string) (string, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "ScheduleWatcher/1.0")
-	req.Header.Set("Accept", "text/html")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("executing request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+// fetch returns the page at pageURL, fetching it unless this poll already has.
+// Failures aren't kept, so a later request for the page tries again.
+func (c *PINSClient) fetch(ctx context.Context, pageURL string) (string, error) {
+	c.mu.Lock()
+	page, ok := c.pages[pageURL]
+	c.mu.Unlock()
+	if ok {
+		return page, nil
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	page, err := c.fetcher.GetLimited(ctx, c.limiter, pageURL, map[string]string{"Accept": "text/html"})
 	if err != nil {
-		return "", fmt.Errorf("reading response body: %w", err)
+		return "", err
 	}
 
-	return string(body), nil
+	c.mu.Lock()
+	c.pages[pageURL] = page
+	c.mu.Unlock()
+	return page, nil
 }
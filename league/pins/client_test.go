@@ -0,0 +1,83 @@
+package pins
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aweist/schedule-watcher/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPINSClientCachesPagesPerPoll(t *testing.T) {
+	requests := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.RawQuery]++
+		w.Write([]byte("page " + r.URL.RawQuery))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, 0)
+	for i := 0; i < 2; i++ {
+		page, err := c.FetchTeamsPage(context.Background(), "123")
+		require.NoError(t, err)
+		assert.Equal(t, "page SCHEDULE_ID=123", page)
+	}
+	_, err := c.FetchTeamSchedule(context.Background(), "123", "9")
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests["SCHEDULE_ID=123"], "teams sharing a season fetch its page once")
+	assert.Equal(t, 1, requests["SCHEDULE_ID=123&TEAM_ID=9"])
+
+	c.StartPoll()
+	_, err = c.FetchTeamsPage(context.Background(), "123")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests["SCHEDULE_ID=123"], "each poll sees the current page")
+}
+
+func TestPINSClientIntervalFollowsReload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("page"))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := NewClient(srv.URL, time.Hour).FetchSchedulesPage(ctx)
+	require.NoError(t, err)
+
+	// The client rebuilt with a shorter interval isn't held to the old one.
+	client.ResetHostLimits()
+	start := time.Now()
+	c := NewClient(srv.URL, 10*time.Millisecond)
+	_, err = c.FetchSchedulesPage(ctx)
+	require.NoError(t, err)
+	_, err = c.FetchTeamsPage(ctx, "123")
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestPINSClientsShareHostInterval(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("page"))
+	}))
+	defer srv.Close()
+	defer client.ResetHostLimits()
+
+	// Two leagues on the same site keep to the slower one's pace together.
+	a := NewClient(srv.URL+"/cgi-bin", 10*time.Millisecond)
+	b := NewClient(srv.URL, 300*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := a.FetchSchedulesPage(ctx)
+	require.NoError(t, err)
+	_, err = b.FetchSchedulesPage(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+}
@@ -2,10 +2,14 @@ package pins
 
 import (
 	"fmt"
+	"log"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
 )
 
 // scheduleOption represents a parsed option from the schedule dropdown.
@@ -16,8 +20,10 @@ type scheduleOption struct {
 
 // teamOption represents a parsed option from the team dropdown.
 type teamOption struct {
-	Value string // TEAM_ID value
-	Text  string // Display text, e.g., "1 - The Sets is Great"
+	Value  string // TEAM_ID value
+	Text   string // Display text, e.g., "1 - The Sets is Great"
+	Number int    // Team number from the display text, e.g., 1
+	Name   string // Display text without the number, e.g., "The Sets is Great"
 }
 
 var (
@@ -25,6 +31,8 @@ var (
 	teamOptionRe     = regexp.MustCompile(`<OPTION\s+VALUE="(\d+)"[^>]*>\s*(.+?)\s*</OPTION>`)
 	// Matches patterns like "Tue Night Mar-May 2026 Season"
 	seasonPatternRe = regexp.MustCompile(`(?i)^(\w+)\s+Night\s+(\w+)-(\w+)\s+(\d{4})\s+Season$`)
+	// Matches the "N - " team number prefix of a team option
+	teamNumberRe = regexp.MustCompile(`^(\d+)\s*-\s*(.*)$`)
 )
 
 // monthIndex maps month abbreviations to month numbers.
@@ -36,21 +44,37 @@ var monthIndex = map[string]time.Month{
 }
 
 // DiscoverCurrentScheduleID finds the current season's SCHEDULE_ID for a given day of week.
-// It parses the schedule dropdown HTML and finds the best match.
-func DiscoverCurrentScheduleID(html string, dayOfWeek string) (string, error) {
+// It parses the schedule dropdown HTML and finds the best match. Seasons
+// begin and end at midnight in loc, the league's time zone.
+func DiscoverCurrentScheduleID(html string, dayOfWeek string, loc *time.Location) (string, error) {
+	options, err := scheduleSelectOptions(html)
+	if err != nil {
+		return "", err
+	}
+	return findBestSchedule(options, dayOfWeek, time.Now().In(loc))
+}
+
+// discoverNextSchedule finds the season for a day of week that follows the
+// one DiscoverCurrentScheduleID picks, if PINS has already posted it.
+func discoverNextSchedule(html string, dayOfWeek string, loc *time.Location) (scheduleOption, bool) {
+	options, err := scheduleSelectOptions(html)
+	if err != nil {
+		return scheduleOption{}, false
+	}
+	return findNextSchedule(options, dayOfWeek, time.Now().In(loc))
+}
+
+func scheduleSelectOptions(html string) ([]scheduleOption, error) {
 	// Find the first SELECT (schedule selector)
 	selectStart := strings.Index(html, `<SELECT NAME=SCHEDULE_ID`)
 	if selectStart == -1 {
-		return "", fmt.Errorf("schedule select not found in HTML")
+		return nil, fmt.Errorf("schedule select not found in HTML")
 	}
 	selectEnd := strings.Index(html[selectStart:], `</SELECT>`)
 	if selectEnd == -1 {
-		return "", fmt.Errorf("schedule select end not found")
+		return nil, fmt.Errorf("schedule select end not found")
 	}
-	selectHTML := html[selectStart : selectStart+selectEnd]
-
-	options := parseScheduleOptions(selectHTML)
-	return findBestSchedule(options, dayOfWeek, time.Now())
+	return parseScheduleOptions(html[selectStart : selectStart+selectEnd]), nil
 }
 
 func parseScheduleOptions(html string) []scheduleOption {
@@ -68,66 +92,102 @@ func parseScheduleOptions(html string) []scheduleOption {
 	return options
 }
 
-// findBestSchedule finds the schedule that matches the day of week and
-// whose date range contains or is closest to the current date.
-func findBestSchedule(options []scheduleOption, dayOfWeek string, now time.Time) (string, error) {
-	dayLower := strings.ToLower(dayOfWeek)
+// seasonInfo is what a season name like "Tue Night Mar-May 2026 Season" says
+// about the season.
+type seasonInfo struct {
+	day   string    // e.g., "Tue"
+	start time.Time // first day of the start month
+	end   time.Time // last moment of the end month
+}
 
-	type scored struct {
-		id    string
-		score int // higher is better: 2 = contains now, 1 = future, 0 = past
-		year  int
-		start time.Month
+// parseSeasonName parses a season name in PINS's usual format, dating the
+// season in loc. ok is false for names in any other format.
+func parseSeasonName(text string, loc *time.Location) (seasonInfo, bool) {
+	m := seasonPatternRe.FindStringSubmatch(text)
+	if m == nil {
+		return seasonInfo{}, false
 	}
 
-	var candidates []scored
+	startMonth, ok1 := monthIndex[strings.ToLower(m[2])]
+	endMonth, ok2 := monthIndex[strings.ToLower(m[3])]
+	year, err := strconv.Atoi(m[4])
+	if !ok1 || !ok2 || err != nil {
+		return seasonInfo{}, false
+	}
 
-	for _, opt := range options {
-		m := seasonPatternRe.FindStringSubmatch(opt.Text)
-		if m == nil {
-			continue
-		}
+	// Build approximate date range for this season
+	seasonStart := time.Date(year, startMonth, 1, 0, 0, 0, 0, loc)
+	// End month: use last day
+	seasonEnd := time.Date(year, endMonth+1, 0, 23, 59, 59, 0, loc)
 
-		optDay := strings.ToLower(m[1])
-		if !strings.HasPrefix(optDay, dayLower[:3]) {
-			continue
-		}
+	// Handle wrap-around seasons (e.g., "Dec-Feb")
+	if endMonth < startMonth {
+		seasonEnd = time.Date(year+1, endMonth+1, 0, 23, 59, 59, 0, loc)
+	}
+
+	return seasonInfo{day: m[1], start: seasonStart, end: seasonEnd}, true
+}
+
+// seasonCandidate is a schedule option for the requested day with its
+// parsed season dates.
+type seasonCandidate struct {
+	option scheduleOption
+	score  int // higher is better: 2 = contains now, 1 = future, 0 = past
+	year   int
+	start  time.Month
+	begins time.Time
+}
+
+// seasonCandidates parses the seasons offered for a day of week and scores
+// each against now. Seasons are dated in now's time zone.
+func seasonCandidates(options []scheduleOption, dayOfWeek string, now time.Time) []seasonCandidate {
+	dayLower := strings.ToLower(dayOfWeek)
+
+	var candidates []seasonCandidate
 
-		startMonth, ok1 := monthIndex[strings.ToLower(m[2])]
-		endMonth, ok2 := monthIndex[strings.ToLower(m[3])]
-		year, err := strconv.Atoi(m[4])
-		if !ok1 || !ok2 || err != nil {
+	for _, opt := range options {
+		info, ok := parseSeasonName(opt.Text, now.Location())
+		if !ok {
 			continue
 		}
 
-		// Build approximate date range for this season
-		seasonStart := time.Date(year, startMonth, 1, 0, 0, 0, 0, time.Local)
-		// End month: use last day
-		seasonEnd := time.Date(year, endMonth+1, 0, 23, 59, 59, 0, time.Local)
-
-		// Handle wrap-around seasons (e.g., "Dec-Feb")
-		if endMonth < startMonth {
-			seasonEnd = time.Date(year+1, endMonth+1, 0, 23, 59, 59, 0, time.Local)
+		if !strings.HasPrefix(strings.ToLower(info.day), dayLower[:3]) {
+			continue
 		}
 
 		score := 0
-		if !now.Before(seasonStart) && !now.After(seasonEnd) {
+		if !now.Before(info.start) && !now.After(info.end) {
 			score = 2 // current season
-		} else if now.Before(seasonStart) {
+		} else if now.Before(info.start) {
 			score = 1 // future season
 		}
 
-		candidates = append(candidates, scored{
-			id:    opt.Value,
-			score: score,
-			year:  year,
-			start: startMonth,
+		candidates = append(candidates, seasonCandidate{
+			option: opt,
+			score:  score,
+			year:   info.start.Year(),
+			start:  info.start.Month(),
+			begins: info.start,
 		})
 	}
 
-	if len(candidates) == 0 {
+	return candidates
+}
+
+// findBestSchedule finds the schedule that matches the day of week and
+// whose date range contains or is closest to the current date.
+func findBestSchedule(options []scheduleOption, dayOfWeek string, now time.Time) (string, error) {
+	best, ok := bestCandidate(seasonCandidates(options, dayOfWeek, now))
+	if !ok {
 		return "", fmt.Errorf("no schedule found matching day %q", dayOfWeek)
 	}
+	return best.option.Value, nil
+}
+
+func bestCandidate(candidates []seasonCandidate) (seasonCandidate, bool) {
+	if len(candidates) == 0 {
+		return seasonCandidate{}, false
+	}
 
 	// Pick best: highest score, then most recent year, then latest start month
 	best := candidates[0]
@@ -143,37 +203,116 @@ func findBestSchedule(options []scheduleOption, dayOfWeek string, now time.Time)
 		}
 	}
 
-	return best.id, nil
+	return best, true
+}
+
+// findNextSchedule finds the earliest future season for the day of week
+// other than the one findBestSchedule picks. When no season is running the
+// best pick is already the upcoming season, so there's no next one to track
+// unless a second future season has been posted.
+func findNextSchedule(options []scheduleOption, dayOfWeek string, now time.Time) (scheduleOption, bool) {
+	candidates := seasonCandidates(options, dayOfWeek, now)
+	best, ok := bestCandidate(candidates)
+	if !ok {
+		return scheduleOption{}, false
+	}
+
+	var next *seasonCandidate
+	for i, c := range candidates {
+		if c.score != 1 || c.option.Value == best.option.Value || !c.begins.After(best.begins) {
+			continue
+		}
+		if next == nil || c.begins.Before(next.begins) {
+			next = &candidates[i]
+		}
+	}
+	if next == nil {
+		return scheduleOption{}, false
+	}
+	return next.option, true
 }
 
 // DiscoverTeamID finds the TEAM_ID for a team by matching team name in the HTML.
 // Uses case-insensitive substring matching.
 func DiscoverTeamID(html string, teamName string) (string, string, error) {
+	m, err := league.NewTeamMatcher(config.TeamEntry{Name: teamName})
+	if err != nil {
+		return "", "", err
+	}
+	return DiscoverTeam(html, m)
+}
+
+// DiscoverTeam finds the TEAM_ID and full team name of the team m matches
+// in the teams page HTML. When several teams match, the first is used and
+// the others are logged so the team's match_mode can be tightened.
+func DiscoverTeam(html string, m league.TeamMatcher) (string, string, error) {
+	options, err := parseTeamOptions(html)
+	if err != nil {
+		return "", "", err
+	}
+
+	var found []teamOption
+	for _, opt := range options {
+		if m.MatchesTeam(opt.Text, opt.Number) || m.MatchesTeam(opt.Name, opt.Number) {
+			found = append(found, opt)
+		}
+	}
+
+	if len(found) == 0 {
+		return "", "", fmt.Errorf("team %q not found in schedule", m.Entry.Name)
+	}
+	if len(found) > 1 {
+		var names []string
+		for _, opt := range found {
+			names = append(names, fmt.Sprintf("%q (%s)", opt.Text, opt.Value))
+		}
+		log.Printf("PINS: warning: %q matches %d teams (%s), using the first; set match_mode or team_id to choose",
+			m.Entry.Name, len(found), strings.Join(names, ", "))
+	}
+	return found[0].Value, found[0].Text, nil
+}
+
+// teamNameByID returns the full name of the team with the given TEAM_ID.
+func teamNameByID(html string, teamID string) (string, error) {
+	options, err := parseTeamOptions(html)
+	if err != nil {
+		return "", err
+	}
+	for _, opt := range options {
+		if opt.Value == teamID {
+			return opt.Text, nil
+		}
+	}
+	return "", fmt.Errorf("team ID %s not found in schedule", teamID)
+}
+
+func parseTeamOptions(html string) ([]teamOption, error) {
 	// Find the TEAM_ID SELECT
 	selectStart := strings.Index(html, `<SELECT NAME=TEAM_ID`)
 	if selectStart == -1 {
-		return "", "", fmt.Errorf("team select not found in HTML")
+		return nil, fmt.Errorf("team select not found in HTML")
 	}
 	selectEnd := strings.Index(html[selectStart:], `</SELECT>`)
 	if selectEnd == -1 {
-		return "", "", fmt.Errorf("team select end not found")
+		return nil, fmt.Errorf("team select end not found")
 	}
 	selectHTML := html[selectStart : selectStart+selectEnd]
 
-	matches := teamOptionRe.FindAllStringSubmatch(selectHTML, -1)
-	teamNameLower := strings.ToLower(teamName)
-
-	for _, m := range matches {
+	var options []teamOption
+	for _, m := range teamOptionRe.FindAllStringSubmatch(selectHTML, -1) {
 		if m[1] == "0" {
 			continue
 		}
 		optText := strings.TrimSpace(m[2])
 		// HTML entities decode
 		optText = strings.ReplaceAll(optText, "&amp;", "&")
-		if strings.Contains(strings.ToLower(optText), teamNameLower) {
-			return m[1], optText, nil
+
+		opt := teamOption{Value: m[1], Text: optText, Name: optText}
+		if n := teamNumberRe.FindStringSubmatch(optText); n != nil {
+			opt.Number, _ = strconv.Atoi(n[1])
+			opt.Name = n[2]
 		}
+		options = append(options, opt)
 	}
-
-	return "", "", fmt.Errorf("team %q not found in schedule", teamName)
+	return options, nil
 }
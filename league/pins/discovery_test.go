@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -29,6 +31,7 @@ const sampleTeamsHTML = `
    <OPTION VALUE="6751" >2 - Pat's Team</OPTION>
    <OPTION VALUE="6770" >3 - Chaddies Baddies</OPTION>
    <OPTION VALUE="6753" >5 - Papa &amp; Family</OPTION>
+   <OPTION VALUE="6788" >12 - Pat's Team 2</OPTION>
 </SELECT>
 `
 
@@ -69,6 +72,29 @@ func TestDiscoverCurrentScheduleID_NoMatch(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestFindNextSchedule(t *testing.T) {
+	options := parseScheduleOptions(sampleScheduleHTML)
+
+	// Mid Jan-Mar season: Mar-May is already posted.
+	now := time.Date(2026, 2, 15, 0, 0, 0, 0, time.Local)
+	current, err := findBestSchedule(options, "Tuesday", now)
+	require.NoError(t, err)
+	assert.Equal(t, "161", current)
+	next, ok := findNextSchedule(options, "Tuesday", now)
+	require.True(t, ok)
+	assert.Equal(t, "169", next.Value)
+	assert.Equal(t, "Tue Night Mar-May 2026 Season", next.Text)
+
+	// Skips ahead over the gap between seasons.
+	next, ok = findNextSchedule(options, "Mon", time.Date(2025, 11, 1, 0, 0, 0, 0, time.Local))
+	require.True(t, ok)
+	assert.Equal(t, "168", next.Value)
+
+	// Nothing after the latest season.
+	_, ok = findNextSchedule(options, "Tuesday", time.Date(2026, 4, 15, 0, 0, 0, 0, time.Local))
+	assert.False(t, ok)
+}
+
 func TestDiscoverTeamID(t *testing.T) {
 	id, fullName, err := DiscoverTeamID(sampleTeamsHTML, "Sets is Great")
 	require.NoError(t, err)
@@ -93,3 +119,78 @@ func TestDiscoverTeamID_HTMLEntities(t *testing.T) {
 	assert.Equal(t, "6753", id)
 	assert.Contains(t, fullName, "Papa & Family")
 }
+
+func discoverTeam(t *testing.T, entry config.TeamEntry) (string, string, error) {
+	m, err := league.NewTeamMatcher(entry)
+	require.NoError(t, err)
+	return DiscoverTeam(sampleTeamsHTML, m)
+}
+
+func TestDiscoverTeam_SubstringPicksFirst(t *testing.T) {
+	// "Pat's Team" is also a substring of "Pat's Team 2".
+	id, _, err := discoverTeam(t, config.TeamEntry{Name: "Pat's Team 2"})
+	require.NoError(t, err)
+	assert.Equal(t, "6788", id)
+
+	id, _, err = discoverTeam(t, config.TeamEntry{Name: "Pat's Team"})
+	require.NoError(t, err)
+	assert.Equal(t, "6751", id)
+}
+
+func TestDiscoverTeam_Exact(t *testing.T) {
+	id, fullName, err := discoverTeam(t, config.TeamEntry{Name: "pat's team", MatchMode: league.MatchExact})
+	require.NoError(t, err)
+	assert.Equal(t, "6751", id)
+	assert.Equal(t, "2 - Pat's Team", fullName)
+
+	_, _, err = discoverTeam(t, config.TeamEntry{Name: "Pat's", MatchMode: league.MatchExact})
+	assert.Error(t, err)
+}
+
+func TestDiscoverTeam_Number(t *testing.T) {
+	id, fullName, err := discoverTeam(t, config.TeamEntry{Name: "Pat", MatchMode: league.MatchNumber, TeamNumber: 12})
+	require.NoError(t, err)
+	assert.Equal(t, "6788", id)
+	assert.Equal(t, "12 - Pat's Team 2", fullName)
+
+	_, _, err = discoverTeam(t, config.TeamEntry{Name: "Pat", MatchMode: league.MatchNumber, TeamNumber: 4})
+	assert.Error(t, err)
+}
+
+func TestTeamNameByID(t *testing.T) {
+	name, err := teamNameByID(sampleTeamsHTML, "6753")
+	require.NoError(t, err)
+	assert.Equal(t, "5 - Papa & Family", name)
+
+	_, err = teamNameByID(sampleTeamsHTML, "1")
+	assert.Error(t, err)
+}
+
+func TestParseSeasonName(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	require.NoError(t, err)
+
+	info, ok := parseSeasonName("Tue Night Nov-Jan 2025 Season", denver)
+	require.True(t, ok)
+	assert.Equal(t, "Tue", info.day)
+	assert.Equal(t, time.Date(2025, 11, 1, 0, 0, 0, 0, denver), info.start)
+	assert.Equal(t, time.Date(2026, 1, 31, 23, 59, 59, 0, denver), info.end)
+
+	_, ok = parseSeasonName("Summer Doubles Bash", denver)
+	assert.False(t, ok)
+}
+
+func TestFindBestSchedule_LeagueTimeZone(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	require.NoError(t, err)
+	options := []scheduleOption{
+		{Value: "summer", Text: "Tue Night Jun-Aug 2026 Season"},
+		{Value: "fall", Text: "Tue Night Sep-Nov 2026 Season"},
+	}
+
+	// Still August 31 in Denver, though September 1 in UTC.
+	now := time.Date(2026, 9, 1, 3, 0, 0, 0, time.UTC).In(denver)
+	id, err := findBestSchedule(options, "Tue", now)
+	require.NoError(t, err)
+	assert.Equal(t, "summer", id)
+}
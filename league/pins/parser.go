@@ -4,6 +4,7 @@ import (
 	"crypto/md5"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,10 +20,22 @@ var (
 	divisionRe = regexp.MustCompile(`(?i)<B><U>Team Division:</U></B>\s*(.+?)(?:\s*&nbsp;|<)`)
 	// Match game time: "MM/DD/YYYY    H:MM" or "MM/DD/YYYY    HH:MM"
 	gameTimeRe = regexp.MustCompile(`(\d{2}/\d{2}/\d{4})\s+(\d{1,2}:\d{2})`)
+
+	// now is stubbed in tests to decide which games have been played.
+	now = time.Now
 )
 
-// ParseSchedule parses the HTML from a PINS team schedule page into games.
-func ParseSchedule(html string, teamKey string, teamName string) ([]models.Game, error) {
+// GamesPerMatch is how many games PINS plays in each scheduled match; the
+// schedule only lists games won, so games lost is the remainder.
+const GamesPerMatch = 3
+
+// scoreGrace is how long after a game PINS is given to post its score. A
+// game still showing 0 games won after that was lost 0-3.
+const scoreGrace = 7 * 24 * time.Hour
+
+// ParseSchedule parses the HTML from a PINS team schedule page into games
+// dated in loc.
+func ParseSchedule(html string, teamKey string, teamName string, loc *time.Location) ([]models.Game, error) {
 	division := parseDivision(html)
 
 	// Find the schedule table - it's the one with "Week" and "Game Time" headers
@@ -32,6 +45,7 @@ func ParseSchedule(html string, teamKey string, teamName string) ([]models.Game,
 	}
 
 	var games []models.Game
+	var gamesWon []string
 	inScheduleTable := false
 
 	for _, row := range rows {
@@ -59,6 +73,10 @@ func ParseSchedule(html string, teamKey string, teamName string) ([]models.Game,
 		gameTimeStr := stripHTML(cells[1][1])
 		courtStr := stripHTML(cells[2][1])
 		opponent := stripHTML(cells[3][1])
+		gamesWonStr := ""
+		if len(cells) > 4 {
+			gamesWonStr = stripHTML(cells[4][1])
+		}
 
 		// Parse game time: "03/17/2026    9:40"
 		m := gameTimeRe.FindStringSubmatch(gameTimeStr)
@@ -69,7 +87,7 @@ func ParseSchedule(html string, teamKey string, teamName string) ([]models.Game,
 		dateStr := m[1]
 		timeStr := m[2]
 
-		gameDate, err := time.Parse("01/02/2006", dateStr)
+		gameDate, err := time.ParseInLocation("01/02/2006", dateStr, loc)
 		if err != nil {
 			continue
 		}
@@ -87,20 +105,70 @@ func ParseSchedule(html string, teamKey string, teamName string) ([]models.Game,
 		games = append(games, models.Game{
 			ID:          gameID,
 			League:      "pins",
+			LeagueType:  "pins",
 			TeamKey:     teamKey,
 			TeamCaptain: teamName,
 			Division:    division,
 			Date:        gameDate,
 			Time:        timeStr,
+			Timezone:    loc.String(),
+			StartsAt:    gameStart(gameDate, timeStr, loc),
 			Court:       court,
 			Opponent:    opponent,
 			Raw:         fmt.Sprintf("%s|%s|%s|%s", dateStr, timeStr, court, opponent),
 		})
+		gamesWon = append(gamesWon, gamesWonStr)
+	}
+
+	// Scores are posted a night at a time, so a 0 on or before the latest
+	// night with a nonzero score is a real 0-3.
+	var lastScored time.Time
+	for i, g := range games {
+		if won, err := strconv.Atoi(strings.TrimSpace(gamesWon[i])); err == nil && won > 0 && g.Date.After(lastScored) {
+			lastScored = g.Date
+		}
+	}
+	for i := range games {
+		games[i].Result = parseResult(gamesWon[i], games[i].Date, !games[i].Date.After(lastScored))
 	}
 
 	return games, nil
 }
 
+// gameStart returns when a game at timeStr (e.g. "9:40") on date starts.
+// PINS lists 12-hour times without am or pm, and its leagues play in the
+// evening, so times from 1:00 to 11:59 are pm.
+func gameStart(date time.Time, timeStr string, loc *time.Location) time.Time {
+	start, _ := models.ParseClock(date, timeStr, loc, true)
+	return start
+}
+
+// parseResult reads the Games Won cell of a played game. PINS shows 0 for
+// games that haven't been played yet, so only games before today count. It
+// also shows 0 for a past game until its score is posted, so a 0 is only
+// taken as a 0-3 loss once scoresPosted says the night's scores are in, or
+// scoreGrace has passed.
+func parseResult(gamesWonStr string, gameDate time.Time, scoresPosted bool) *models.GameResult {
+	won, err := strconv.Atoi(strings.TrimSpace(gamesWonStr))
+	if err != nil || won < 0 {
+		return nil
+	}
+	if won == 0 && !scoresPosted && now().Sub(gameDate) < scoreGrace {
+		return nil
+	}
+
+	y, m, d := now().In(gameDate.Location()).Date()
+	if !gameDate.Before(time.Date(y, m, d, 0, 0, 0, 0, gameDate.Location())) {
+		return nil
+	}
+
+	lost := GamesPerMatch - won
+	if lost < 0 {
+		lost = 0
+	}
+	return &models.GameResult{GamesWon: won, GamesLost: lost}
+}
+
 func parseDivision(html string) string {
 	m := divisionRe.FindStringSubmatch(html)
 	if m != nil {
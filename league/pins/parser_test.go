@@ -1,8 +1,11 @@
 package pins
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aweist/schedule-watcher/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -61,7 +64,7 @@ const sampleSchedulePageHTML = `
 `
 
 func TestParseSchedule(t *testing.T) {
-	games, err := ParseSchedule(sampleSchedulePageHTML, "tue-sets-is-great", "1 - The Sets is Great")
+	games, err := ParseSchedule(sampleSchedulePageHTML, "tue-sets-is-great", "1 - The Sets is Great", time.UTC)
 	require.NoError(t, err)
 	assert.Len(t, games, 5)
 
@@ -79,8 +82,22 @@ func TestParseSchedule(t *testing.T) {
 	assert.Equal(t, "2 - Pat's Team", g.Opponent)
 }
 
+func TestParseSchedule_Location(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	require.NoError(t, err)
+
+	games, err := ParseSchedule(sampleSchedulePageHTML, "test", "Test Team", denver)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2026, 3, 17, 0, 0, 0, 0, denver), games[0].Date)
+	assert.Equal(t, "America/Denver", games[0].Timezone)
+	// "9:40" is in the evening.
+	assert.Equal(t, time.Date(2026, 3, 17, 21, 40, 0, 0, denver), games[0].StartsAt)
+	assert.Equal(t, denver, games[0].Location())
+}
+
 func TestParseSchedule_HTMLEntitiesInOpponent(t *testing.T) {
-	games, err := ParseSchedule(sampleSchedulePageHTML, "test", "Test Team")
+	games, err := ParseSchedule(sampleSchedulePageHTML, "test", "Test Team", time.UTC)
 	require.NoError(t, err)
 
 	// Third game has "Papa & Family" (HTML entity)
@@ -88,7 +105,7 @@ func TestParseSchedule_HTMLEntitiesInOpponent(t *testing.T) {
 }
 
 func TestParseSchedule_MultipleGamesSameDay(t *testing.T) {
-	games, err := ParseSchedule(sampleSchedulePageHTML, "test", "Test Team")
+	games, err := ParseSchedule(sampleSchedulePageHTML, "test", "Test Team", time.UTC)
 	require.NoError(t, err)
 
 	// Games 3 and 4 are both on 04/14/2026
@@ -99,7 +116,7 @@ func TestParseSchedule_MultipleGamesSameDay(t *testing.T) {
 }
 
 func TestParseSchedule_Division(t *testing.T) {
-	games, err := ParseSchedule(sampleSchedulePageHTML, "test", "Test Team")
+	games, err := ParseSchedule(sampleSchedulePageHTML, "test", "Test Team", time.UTC)
 	require.NoError(t, err)
 
 	for _, g := range games {
@@ -108,7 +125,7 @@ func TestParseSchedule_Division(t *testing.T) {
 }
 
 func TestParseSchedule_GameIDs(t *testing.T) {
-	games, err := ParseSchedule(sampleSchedulePageHTML, "test", "Test Team")
+	games, err := ParseSchedule(sampleSchedulePageHTML, "test", "Test Team", time.UTC)
 	require.NoError(t, err)
 
 	// All IDs should be unique and start with "pins-"
@@ -121,8 +138,74 @@ func TestParseSchedule_GameIDs(t *testing.T) {
 	}
 }
 
+func TestParseSchedule_Results(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return time.Date(2026, 4, 14, 9, 0, 0, 0, time.UTC) }
+
+	games, err := ParseSchedule(sampleSchedulePageHTML, "test", "Test Team", time.UTC)
+	require.NoError(t, err)
+
+	require.NotNil(t, games[0].Result)
+	assert.Equal(t, 3, games[0].Result.GamesWon)
+	assert.Equal(t, 0, games[0].Result.GamesLost)
+
+	require.NotNil(t, games[2].Result)
+	assert.Equal(t, 1, games[2].Result.GamesWon)
+	assert.Equal(t, 2, games[2].Result.GamesLost)
+
+	// Today's games show 0 until scores are posted.
+	assert.Nil(t, games[3].Result)
+	assert.Nil(t, games[4].Result)
+}
+
+func TestParseResult(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return time.Date(2026, 4, 14, 9, 0, 0, 0, time.UTC) }
+
+	played := time.Date(2026, 4, 7, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, &models.GameResult{GamesWon: 2, GamesLost: 1}, parseResult(" 2 ", played, true))
+	assert.Equal(t, &models.GameResult{GamesWon: 0, GamesLost: 3}, parseResult("0", played, true))
+	assert.Nil(t, parseResult("", played, true))
+	assert.Nil(t, parseResult("-", played, true))
+	assert.Nil(t, parseResult("2", time.Date(2026, 4, 21, 0, 0, 0, 0, time.UTC), true))
+}
+
+func TestParseResult_PastGameNotPosted(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return time.Date(2026, 4, 14, 9, 0, 0, 0, time.UTC) }
+
+	// Yesterday's game shows the default 0 until its score is posted, but a
+	// nonzero score is posted as soon as it shows.
+	yesterday := time.Date(2026, 4, 13, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, parseResult("0", yesterday, false))
+	assert.Equal(t, &models.GameResult{GamesWon: 1, GamesLost: 2}, parseResult("1", yesterday, false))
+
+	// A 0 still showing after the grace period is a loss.
+	assert.Equal(t, &models.GameResult{GamesWon: 0, GamesLost: 3},
+		parseResult(" 0 ", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), false))
+}
+
+func TestParseSchedule_ZeroPostedWithNight(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return time.Date(2026, 4, 15, 9, 0, 0, 0, time.UTC) }
+
+	// Once the 04/14 7:55 score is posted, the 8:50 game's 0 is a loss.
+	html := strings.Replace(sampleSchedulePageHTML,
+		"Goose Bumps</TD>\n      <TD ALIGN=CENTER>0", "Goose Bumps</TD>\n      <TD ALIGN=CENTER>2", 1)
+	require.NotEqual(t, sampleSchedulePageHTML, html)
+	games, err := ParseSchedule(html, "test", "Test Team", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, &models.GameResult{GamesWon: 2, GamesLost: 1}, games[3].Result)
+	assert.Equal(t, &models.GameResult{GamesWon: 0, GamesLost: 3}, games[4].Result)
+
+	// Without it, the 0 waits to be posted.
+	games, err = ParseSchedule(sampleSchedulePageHTML, "test", "Test Team", time.UTC)
+	require.NoError(t, err)
+	assert.Nil(t, games[4].Result)
+}
+
 func TestParseSchedule_EmptyHTML(t *testing.T) {
-	_, err := ParseSchedule("<html></html>", "test", "Test")
+	_, err := ParseSchedule("<html></html>", "test", "Test", time.UTC)
 	assert.Error(t, err)
 }
 
@@ -1,22 +1,66 @@
 package pins
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/aweist/schedule-watcher/config"
 	"github.com/aweist/schedule-watcher/league"
 	"github.com/aweist/schedule-watcher/models"
 )
 
+// defaultGameDuration is a PINS match of GamesPerMatch games, which are
+// scheduled 55 minutes apart.
+const defaultGameDuration = 55 * time.Minute
+
+func init() {
+	league.Register("pins", league.Type{
+		Description: "PINS facility schedules.cgi pages, discovered by season day and team name",
+		RequiredAPI: []string{"base_url"},
+		OptionalAPI: []string{"request_interval"},
+		Validate: func(cfg config.LeagueConfig) []string {
+			var problems []string
+			if _, err := requestInterval(cfg); err != nil {
+				problems = append(problems, fmt.Sprintf("api.request_interval: %v", err))
+			}
+			for i, t := range cfg.Teams {
+				if t.Day == "" && t.ScheduleID == "" {
+					problems = append(problems, fmt.Sprintf("teams[%d].day is required for pins leagues unless schedule_id is set", i))
+				}
+				if t.Division != "" {
+					problems = append(problems, fmt.Sprintf("teams[%d].division is not supported for pins leagues", i))
+				}
+				if _, err := league.NewTeamMatcher(t); err != nil {
+					problems = append(problems, fmt.Sprintf("teams[%d].match: %v", i, err))
+				}
+			}
+			return problems
+		},
+		Factory: func(name string, cfg config.LeagueConfig) (league.League, error) {
+			lg, err := New(name, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return lg, nil
+		},
+	})
+}
+
 type PINSLeague struct {
 	name         string
 	displayName  string
 	notifyMode   string
 	reminderTime string
+	notifyResult bool
 	client       *PINSClient
 	teams        []league.TeamConfig
-	teamEntries  []config.TeamEntry
+	matchers     []league.TeamMatcher
+	location     *time.Location
+	gameDuration time.Duration
+	upcoming     []models.Season
 }
 
 func New(name string, cfg config.LeagueConfig) (*PINSLeague, error) {
@@ -33,80 +77,314 @@ func New(name string, cfg config.LeagueConfig) (*PINSLeague, error) {
 		})
 	}
 
+	matchers, err := league.NewTeamMatchers(cfg.Teams)
+	if err != nil {
+		return nil, err
+	}
+	location, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+	gameDuration, err := cfg.GameLength(defaultGameDuration)
+	if err != nil {
+		return nil, fmt.Errorf("game_duration: %w", err)
+	}
+	interval, err := requestInterval(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("api.request_interval: %w", err)
+	}
+
 	notifyMode := cfg.NotifyMode
 	if notifyMode == "" {
 		notifyMode = league.NotifyImmediate
 	}
 
 	return &PINSLeague{
-		name:         "pins",
+		name:         cfg.StorageNamespace(name),
 		displayName:  name,
 		notifyMode:   notifyMode,
 		reminderTime: cfg.ReminderTime,
-		client:       NewClient(baseURL),
+		notifyResult: cfg.NotifyResults,
+		client:       NewClient(baseURL, interval),
 		teams:        teams,
-		teamEntries:  cfg.Teams,
+		matchers:     matchers,
+		location:     location,
+		gameDuration: gameDuration,
 	}, nil
 }
 
+// requestInterval returns the least time between requests to the league's
+// site: api.request_interval, or DefaultRequestInterval.
+func requestInterval(cfg config.LeagueConfig) (time.Duration, error) {
+	v := cfg.API["request_interval"]
+	if v == "" {
+		return DefaultRequestInterval, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%q is not a duration", v)
+	}
+	return d, nil
+}
+
 func (l *PINSLeague) Name() string               { return l.name }
 func (l *PINSLeague) DisplayName() string        { return l.displayName }
 func (l *PINSLeague) NotifyMode() string         { return l.notifyMode }
 func (l *PINSLeague) ReminderTime() string       { return l.reminderTime }
 func (l *PINSLeague) Teams() []league.TeamConfig { return l.teams }
+func (l *PINSLeague) NotifyResults() bool        { return l.notifyResult }
+func (l *PINSLeague) Location() *time.Location   { return l.location }
+
+// GameTimes implements league.GameTimer.
+func (l *PINSLeague) GameTimes(game models.Game) (time.Time, time.Time, bool) {
+	start := gameStart(game.Date, game.Time, l.location)
+	if start.IsZero() {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(l.gameDuration), true
+}
+
+// UpcomingSeasons implements league.SeasonProvider.
+func (l *PINSLeague) UpcomingSeasons() []models.Season { return l.upcoming }
+
+func (l *PINSLeague) FetchAndParse(ctx context.Context) (map[string][]models.Game, error) {
+	l.client.StartPoll()
 
-func (l *PINSLeague) FetchAndParse() (map[string][]models.Game, error) {
 	// Step 1: Fetch the main schedules page for season discovery
-	schedulesHTML, err := l.client.FetchSchedulesPage()
+	schedulesHTML, err := l.client.FetchSchedulesPage(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("fetching schedules page: %w", err)
 	}
 
 	result := make(map[string][]models.Game)
+	var upcoming []models.Season
+	var errs []error
 
-	for _, team := range l.teamEntries {
-		games, err := l.fetchTeamGames(schedulesHTML, team)
+	for _, m := range l.matchers {
+		team := m.Entry
+		games, err := l.fetchTeamGames(ctx, schedulesHTML, m)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		if err != nil {
 			log.Printf("Error fetching PINS games for team %s (%s): %v", team.Key, team.Name, err)
+			errs = append(errs, fmt.Errorf("team %s: %w", team.Key, err))
 			continue
 		}
 		result[team.Key] = games
+
+		if season, ok := l.nextSeason(ctx, schedulesHTML, m); ok {
+			upcoming = append(upcoming, season)
+			result[team.Key] = append(result[team.Key], season.Games...)
+		}
 	}
 
-	return result, nil
+	l.upcoming = upcoming
+	switch {
+	case len(errs) == 0:
+		return result, nil
+	case len(result) == 0:
+		return nil, fmt.Errorf("no team could be fetched: %w", errors.Join(errs...))
+	default:
+		return result, &league.PartialError{Err: errors.Join(errs...)}
+	}
 }
 
-func (l *PINSLeague) fetchTeamGames(schedulesHTML string, team config.TeamEntry) ([]models.Game, error) {
-	// Step 2: Discover the current SCHEDULE_ID for this team's day
-	scheduleID, err := DiscoverCurrentScheduleID(schedulesHTML, team.Day)
+func (l *PINSLeague) fetchTeamGames(ctx context.Context, schedulesHTML string, m league.TeamMatcher) ([]models.Game, error) {
+	team := m.Entry
+
+	// Step 2: Use the pinned SCHEDULE_ID, or discover the current one for this team's day
+	scheduleID, err := l.scheduleID(schedulesHTML, team)
 	if err != nil {
-		return nil, fmt.Errorf("discovering schedule for %s: %w", team.Day, err)
+		return nil, err
 	}
-	log.Printf("PINS: discovered schedule ID %s for %s night", scheduleID, team.Day)
 
-	// Step 3: Fetch the teams page and discover the TEAM_ID
-	teamsHTML, err := l.client.FetchTeamsPage(scheduleID)
+	return l.fetchScheduleGames(ctx, scheduleID, m)
+}
+
+// fetchScheduleGames fetches a team's games in one season.
+func (l *PINSLeague) fetchScheduleGames(ctx context.Context, scheduleID string, m league.TeamMatcher) ([]models.Game, error) {
+	team := m.Entry
+
+	// Step 3: Fetch the teams page and find the TEAM_ID
+	teamsHTML, err := l.client.FetchTeamsPage(ctx, scheduleID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching teams page: %w", err)
 	}
 
-	teamID, fullTeamName, err := DiscoverTeamID(teamsHTML, team.Name)
+	teamID, fullTeamName, err := l.teamID(teamsHTML, m)
 	if err != nil {
-		return nil, fmt.Errorf("discovering team ID for %q: %w", team.Name, err)
+		return nil, err
 	}
-	log.Printf("PINS: discovered team ID %s (%s) for %q", teamID, fullTeamName, team.Name)
 
 	// Step 4: Fetch and parse the team schedule
-	scheduleHTML, err := l.client.FetchTeamSchedule(scheduleID, teamID)
+	scheduleHTML, err := l.client.FetchTeamSchedule(ctx, scheduleID, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching team schedule: %w", err)
 	}
 
-	games, err := ParseSchedule(scheduleHTML, team.Key, fullTeamName)
+	games, err := l.parseTeamSchedule(scheduleHTML, team.Key, fullTeamName)
 	if err != nil {
-		return nil, fmt.Errorf("parsing team schedule: %w", err)
+		return nil, err
 	}
 
 	log.Printf("PINS: found %d games for team %s (%s)", len(games), team.Key, fullTeamName)
 	return games, nil
 }
+
+// parseTeamSchedule parses a team schedule page into the team's games.
+func (l *PINSLeague) parseTeamSchedule(scheduleHTML, teamKey, teamName string) ([]models.Game, error) {
+	games, err := ParseSchedule(scheduleHTML, teamKey, teamName, l.location)
+	if err != nil {
+		return nil, fmt.Errorf("parsing team schedule: %w", err)
+	}
+	for i := range games {
+		games[i].League = l.name
+		games[i].LeagueName = l.displayName
+		if !games[i].StartsAt.IsZero() {
+			games[i].EndsAt = games[i].StartsAt.Add(l.gameDuration)
+		}
+	}
+	return games, nil
+}
+
+// ParseRaw implements league.RawParser for a saved team schedule page. The
+// page doesn't name its team, so it is read as the league's only team's.
+func (l *PINSLeague) ParseRaw(scheduleHTML string) (map[string][]models.Game, error) {
+	if len(l.matchers) != 1 {
+		return nil, fmt.Errorf("a PINS schedule page is one team's, so the league must have exactly one team (has %d)", len(l.matchers))
+	}
+	team := l.matchers[0].Entry
+	games, err := l.parseTeamSchedule(scheduleHTML, team.Key, team.Name)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]models.Game{team.Key: games}, nil
+}
+
+// Discover implements league.Discoverer, listing every season in the
+// schedule dropdown with the teams of each current or upcoming one. Seasons
+// whose names aren't in the usual "Tue Night Mar-May 2026 Season" format
+// can't be discovered by day, so their teams are always listed for pinning.
+func (l *PINSLeague) Discover(ctx context.Context, allSeasons bool) ([]league.DiscoveredSeason, error) {
+	l.client.StartPoll()
+	schedulesHTML, err := l.client.FetchSchedulesPage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching schedules page: %w", err)
+	}
+	options, err := scheduleSelectOptions(schedulesHTML)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(l.location)
+	var seasons []league.DiscoveredSeason
+	for _, opt := range options {
+		season := league.DiscoveredSeason{ID: opt.Value, Name: opt.Text}
+		if info, ok := parseSeasonName(opt.Text, l.location); ok {
+			season.Day = info.day
+			season.Past = now.After(info.end)
+		}
+
+		if !season.Past || allSeasons {
+			teamsHTML, err := l.client.FetchTeamsPage(ctx, opt.Value)
+			if err != nil {
+				return nil, fmt.Errorf("fetching teams for %q: %w", opt.Text, err)
+			}
+			teams, err := parseTeamOptions(teamsHTML)
+			if err != nil {
+				return nil, fmt.Errorf("reading teams for %q: %w", opt.Text, err)
+			}
+			for _, t := range teams {
+				season.Teams = append(season.Teams, league.DiscoveredTeam{
+					ID:     t.Value,
+					Name:   t.Name,
+					Number: t.Number,
+				})
+			}
+		}
+
+		seasons = append(seasons, season)
+	}
+	return seasons, nil
+}
+
+// nextSeason fetches the team's games in the season after the current one,
+// if PINS has posted it and the team is on it. Team IDs change every season,
+// so the team is found by name even if its current team ID is pinned. Teams
+// pinned to a schedule ID don't look ahead.
+func (l *PINSLeague) nextSeason(ctx context.Context, schedulesHTML string, m league.TeamMatcher) (models.Season, bool) {
+	team := m.Entry
+	if team.Day == "" || team.ScheduleID != "" {
+		return models.Season{}, false
+	}
+
+	next, ok := discoverNextSchedule(schedulesHTML, team.Day, l.location)
+	if !ok {
+		return models.Season{}, false
+	}
+
+	m.Entry.TeamID = ""
+	games, err := l.fetchScheduleGames(ctx, next.Value, m)
+	if err != nil {
+		log.Printf("PINS: team %s not found in next season %q (%s): %v", team.Key, next.Text, next.Value, err)
+		return models.Season{}, false
+	}
+	if len(games) == 0 {
+		return models.Season{}, false
+	}
+
+	return models.Season{
+		League:     l.name,
+		LeagueName: l.displayName,
+		TeamKey:    team.Key,
+		ScheduleID: next.Value,
+		Name:       next.Text,
+		Games:      games,
+	}, true
+}
+
+// scheduleID returns the team's pinned schedule ID if set, otherwise the one
+// discovered for its day. Discovery still runs alongside a pinned ID so a
+// disagreement (usually a new season) shows up in the logs.
+func (l *PINSLeague) scheduleID(schedulesHTML string, team config.TeamEntry) (string, error) {
+	if team.Day == "" {
+		return team.ScheduleID, nil
+	}
+
+	discovered, err := DiscoverCurrentScheduleID(schedulesHTML, team.Day, l.location)
+	if team.ScheduleID != "" {
+		if err == nil && discovered != team.ScheduleID {
+			log.Printf("PINS: warning: team %s is pinned to schedule ID %s but discovery picked %s for %s night", team.Key, team.ScheduleID, discovered, team.Day)
+		}
+		return team.ScheduleID, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("discovering schedule for %s: %w", team.Day, err)
+	}
+	log.Printf("PINS: discovered schedule ID %s for %s night", discovered, team.Day)
+	return discovered, nil
+}
+
+// teamID returns the team's pinned team ID if set, otherwise the one whose
+// name matches, along with the team's full name from the teams page.
+func (l *PINSLeague) teamID(teamsHTML string, m league.TeamMatcher) (string, string, error) {
+	team := m.Entry
+	discovered, fullTeamName, err := DiscoverTeam(teamsHTML, m)
+	if team.TeamID == "" {
+		if err != nil {
+			return "", "", fmt.Errorf("discovering team ID for %q: %w", team.Name, err)
+		}
+		log.Printf("PINS: discovered team ID %s (%s) for %q", discovered, fullTeamName, team.Name)
+		return discovered, fullTeamName, nil
+	}
+
+	if err == nil && discovered != team.TeamID {
+		log.Printf("PINS: warning: team %s is pinned to team ID %s but discovery picked %s (%s)", team.Key, team.TeamID, discovered, fullTeamName)
+	}
+	pinnedName, err := teamNameByID(teamsHTML, team.TeamID)
+	if err != nil {
+		return "", "", err
+	}
+	return team.TeamID, pinnedName, nil
+}
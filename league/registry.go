@@ -0,0 +1,96 @@
+package league
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aweist/schedule-watcher/config"
+)
+
+// Factory builds a league from its config entry. name is the entry's key in
+// the config file and is used as the display name.
+type Factory func(name string, cfg config.LeagueConfig) (League, error)
+
+// Type describes a league implementation registered under a config type.
+type Type struct {
+	// Name is the value of "type" in the config; filled in by Register.
+	Name string
+
+	// Description is a one-line summary shown in the admin UI.
+	Description string
+
+	// RequiredAPI lists the api keys the type cannot run without.
+	RequiredAPI []string
+
+	// OptionalAPI lists api keys the type understands but can default.
+	OptionalAPI []string
+
+	// Validate optionally checks type-specific settings beyond RequiredAPI,
+	// returning a description of each problem.
+	Validate func(cfg config.LeagueConfig) []string
+
+	Factory Factory
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Type)
+)
+
+// Register makes a league type available under name. Implementations call
+// it from init, so importing the package is enough to enable the type.
+// Registering the same name twice panics.
+func Register(name string, t Type) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if t.Factory == nil {
+		panic(fmt.Sprintf("league: Register %q with nil factory", name))
+	}
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("league: Register called twice for type %q", name))
+	}
+	t.Name = name
+	registry[name] = t
+}
+
+// Lookup returns the registered type with the given name.
+func Lookup(name string) (Type, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	t, ok := registry[name]
+	return t, ok
+}
+
+// Types returns every registered type sorted by name.
+func Types() []Type {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]Type, 0, len(registry))
+	for _, t := range registry {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+	return types
+}
+
+// Build constructs the league for a config entry using its registered type.
+func Build(name string, cfg config.LeagueConfig) (League, error) {
+	t, ok := Lookup(cfg.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown league type %q", cfg.Type)
+	}
+	return t.Factory(name, cfg)
+}
+
+func typeNames() []string {
+	var names []string
+	for _, t := range Types() {
+		names = append(names, t.Name)
+	}
+	return names
+}
@@ -0,0 +1,80 @@
+package league
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLeague struct {
+	name string
+}
+
+func (s *stubLeague) Name() string         { return s.name }
+func (s *stubLeague) DisplayName() string  { return s.name }
+func (s *stubLeague) NotifyMode() string   { return NotifyImmediate }
+func (s *stubLeague) ReminderTime() string { return "" }
+func (s *stubLeague) FetchAndParse(context.Context) (map[string][]models.Game, error) {
+	return nil, nil
+}
+func (s *stubLeague) Teams() []TeamConfig { return nil }
+
+func init() {
+	Register("test-secret", Type{
+		RequiredAPI: []string{"instance", "comp_id"},
+		Factory: func(name string, cfg config.LeagueConfig) (League, error) {
+			return &stubLeague{name: name}, nil
+		},
+	})
+	Register("test-daily", Type{
+		RequiredAPI: []string{"base_url"},
+		Validate: func(cfg config.LeagueConfig) []string {
+			var problems []string
+			for i, t := range cfg.Teams {
+				if t.Day == "" {
+					problems = append(problems, fmt.Sprintf("teams[%d].day is required", i))
+				}
+			}
+			return problems
+		},
+		Factory: func(name string, cfg config.LeagueConfig) (League, error) {
+			return nil, fmt.Errorf("not buildable")
+		},
+	})
+}
+
+func TestRegistry_Build(t *testing.T) {
+	lg, err := Build("My League", config.LeagueConfig{Type: "test-secret"})
+	require.NoError(t, err)
+	assert.Equal(t, "My League", lg.DisplayName())
+
+	_, err = Build("Broken", config.LeagueConfig{Type: "test-daily"})
+	assert.EqualError(t, err, "not buildable")
+
+	_, err = Build("Nope", config.LeagueConfig{Type: "bowling"})
+	assert.EqualError(t, err, `unknown league type "bowling"`)
+}
+
+func TestRegistry_TypesSortedWithNames(t *testing.T) {
+	types := Types()
+	require.GreaterOrEqual(t, len(types), 2)
+	for i := 1; i < len(types); i++ {
+		assert.Less(t, types[i-1].Name, types[i].Name)
+	}
+
+	typ, ok := Lookup("test-secret")
+	require.True(t, ok)
+	assert.Equal(t, "test-secret", typ.Name)
+	assert.Equal(t, []string{"instance", "comp_id"}, typ.RequiredAPI)
+}
+
+func TestRegistry_DuplicatePanics(t *testing.T) {
+	assert.Panics(t, func() {
+		Register("test-secret", Type{Factory: func(string, config.LeagueConfig) (League, error) { return nil, nil }})
+	})
+}
@@ -0,0 +1,251 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/models"
+	"github.com/aweist/schedule-watcher/storage"
+)
+
+// fetchKeys are the source api keys that only say where to fetch from. A
+// replay never fetches, so they're filled in when the config leaves them out.
+var fetchKeys = []string{"url", "base_url"}
+
+// rawlessSources are the source types that don't implement
+// league.RawDataProvider, so their snapshots are saved without the fetched
+// data and only their fixture files can be replayed. New checks the same
+// thing on the built source; listing them here lets Validate say so first.
+var rawlessSources = map[string]bool{"pins": true}
+
+func init() {
+	league.Register("replay", league.Type{
+		Description: "Replays another type's saved payloads (fixture files or stored snapshots), one per poll, without fetching",
+		RequiredAPI: []string{"source"},
+		OptionalAPI: []string{"dir", "database", "snapshots", "<source type's keys>"},
+		Validate: func(cfg config.LeagueConfig) []string {
+			var problems []string
+			if (cfg.API["dir"] == "") == (cfg.API["database"] == "") {
+				problems = append(problems, "exactly one of api.dir and api.database is required for replay leagues")
+			}
+			if cfg.API["snapshots"] != "" && cfg.API["database"] == "" {
+				problems = append(problems, "api.snapshots only applies with api.database")
+			}
+			source := cfg.API["source"]
+			if source == "" {
+				return problems
+			}
+			t, ok := league.Lookup(source)
+			if !ok || source == "replay" {
+				return append(problems, fmt.Sprintf("api.source: %q is not a league type that can be replayed", source))
+			}
+			if cfg.API["database"] != "" && rawlessSources[source] {
+				problems = append(problems, rawlessProblem(source))
+			}
+			if t.Validate != nil {
+				problems = append(problems, t.Validate(sourceConfig(cfg))...)
+			}
+			return problems
+		},
+		Factory: func(name string, cfg config.LeagueConfig) (league.League, error) {
+			lg, err := New(name, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return lg, nil
+		},
+	})
+}
+
+// step is one upstream payload in the replay.
+type step struct {
+	label string // file name or snapshot ID, for logs
+	raw   string
+}
+
+// ReplayLeague serves FetchAndParse from saved payloads instead of the
+// network, parsing each with a league of the source type. Each poll moves
+// to the next payload; once they run out, the last one is served again.
+type ReplayLeague struct {
+	source  league.League
+	parser  league.RawParser
+	steps   []step
+	next    int // index of the step the next poll serves
+	lastRaw string
+}
+
+func New(name string, cfg config.LeagueConfig) (*ReplayLeague, error) {
+	sourceType := cfg.API["source"]
+	t, ok := league.Lookup(sourceType)
+	if !ok || sourceType == "replay" {
+		return nil, fmt.Errorf("api.source: %q is not a league type that can be replayed", sourceType)
+	}
+
+	source, err := t.Factory(name, sourceConfig(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("building %s source: %w", sourceType, err)
+	}
+	parser, ok := source.(league.RawParser)
+	if !ok {
+		return nil, fmt.Errorf("%s leagues can't parse saved payloads, so they can't be replayed", sourceType)
+	}
+
+	var steps []step
+	if dir := cfg.API["dir"]; dir != "" {
+		steps, err = readFixtures(dir)
+	} else {
+		if _, ok := source.(league.RawDataProvider); !ok {
+			return nil, fmt.Errorf("%s", rawlessProblem(sourceType))
+		}
+		namespace := cfg.API["snapshots"]
+		if namespace == "" {
+			namespace = source.Name()
+		}
+		steps, err = readSnapshots(cfg.API["database"], namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplayLeague{
+		source: source,
+		parser: parser,
+		steps:  steps,
+	}, nil
+}
+
+// rawlessProblem explains why a source type's snapshots can't be replayed.
+func rawlessProblem(sourceType string) string {
+	return fmt.Sprintf("api.database: %s snapshots are saved without the fetched pages, so they can't be replayed; save the pages as fixture files in api.dir instead", sourceType)
+}
+
+// sourceConfig returns cfg as the source type sees it.
+func sourceConfig(cfg config.LeagueConfig) config.LeagueConfig {
+	api := make(map[string]string, len(cfg.API))
+	for key, v := range cfg.API {
+		api[key] = v
+	}
+	for _, key := range fetchKeys {
+		if api[key] == "" {
+			api[key] = "replay:"
+		}
+	}
+	cfg.Type = cfg.API["source"]
+	cfg.API = api
+	return cfg
+}
+
+// readFixtures returns the files in dir in name order, skipping
+// subdirectories and hidden files.
+func readFixtures(dir string) ([]step, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading fixtures: %w", err)
+	}
+
+	var steps []step
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading fixtures: %w", err)
+		}
+		steps = append(steps, step{label: e.Name(), raw: string(data)})
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no fixture files in %s", dir)
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].label < steps[j].label })
+	return steps, nil
+}
+
+// readSnapshots returns the namespace's snapshots in the order they were
+// fetched. Snapshots saved without raw data can't be parsed and are skipped.
+func readSnapshots(dbPath, namespace string) ([]step, error) {
+	snapshots, err := storage.ReadSnapshots(dbPath, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshots: %w", err)
+	}
+
+	var steps []step
+	for _, snap := range snapshots {
+		if snap.CSVData == "" {
+			continue
+		}
+		label := fmt.Sprintf("%s from %s", snap.ID, snap.FetchedAt.Format(time.RFC3339))
+		steps = append(steps, step{label: label, raw: snap.CSVData})
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no snapshots of %s with raw data in %s", namespace, dbPath)
+	}
+	if skipped := len(snapshots) - len(steps); skipped > 0 {
+		log.Printf("Replay: skipping %d snapshot(s) of %s saved without raw data", skipped, namespace)
+	}
+	return steps, nil
+}
+
+func (l *ReplayLeague) Name() string               { return l.source.Name() }
+func (l *ReplayLeague) DisplayName() string        { return l.source.DisplayName() }
+func (l *ReplayLeague) NotifyMode() string         { return l.source.NotifyMode() }
+func (l *ReplayLeague) ReminderTime() string       { return l.source.ReminderTime() }
+func (l *ReplayLeague) Teams() []league.TeamConfig { return l.source.Teams() }
+func (l *ReplayLeague) LastRawData() string        { return l.lastRaw }
+func (l *ReplayLeague) Location() *time.Location   { return league.Location(l.source) }
+
+// LastStandings implements league.StandingsProvider for sources that have it.
+func (l *ReplayLeague) LastStandings() []models.Standing {
+	if p, ok := l.source.(league.StandingsProvider); ok {
+		return p.LastStandings()
+	}
+	return nil
+}
+
+// UpcomingSeasons implements league.SeasonProvider for sources that have it.
+func (l *ReplayLeague) UpcomingSeasons() []models.Season {
+	if p, ok := l.source.(league.SeasonProvider); ok {
+		return p.UpcomingSeasons()
+	}
+	return nil
+}
+
+// NotifyResults implements league.ResultReporter for sources that have it.
+func (l *ReplayLeague) NotifyResults() bool {
+	r, ok := l.source.(league.ResultReporter)
+	return ok && r.NotifyResults()
+}
+
+// GameTimes implements league.GameTimer for sources that have it.
+func (l *ReplayLeague) GameTimes(game models.Game) (time.Time, time.Time, bool) {
+	if t, ok := l.source.(league.GameTimer); ok {
+		return t.GameTimes(game)
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// FetchAndParse parses the next saved payload, or the last one again once
+// the replay has reached the end.
+func (l *ReplayLeague) FetchAndParse(ctx context.Context) (map[string][]models.Game, error) {
+	i := l.next
+	if i < len(l.steps) {
+		log.Printf("Replay: %s step %d of %d (%s)", l.DisplayName(), i+1, len(l.steps), l.steps[i].label)
+		if i == len(l.steps)-1 {
+			log.Printf("Replay: %s reached its last step; later polls repeat it", l.DisplayName())
+		}
+		l.next++
+	} else {
+		i = len(l.steps) - 1
+	}
+
+	l.lastRaw = l.steps[i].raw
+	return l.parser.ParseRaw(l.lastRaw)
+}
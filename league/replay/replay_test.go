@@ -0,0 +1,116 @@
+package replay
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/models"
+	"github.com/aweist/schedule-watcher/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/aweist/schedule-watcher/league/csvfeed"
+	_ "github.com/aweist/schedule-watcher/league/pins"
+)
+
+const (
+	firstSheet = `Date,Time,Field,Home,Away
+3/10/2026,7:00 PM,Court 1,Dig Dug,Net Results
+`
+	secondSheet = firstSheet + `3/17/2026,8:00 PM,Court 2,Block Party,Dig Dug
+`
+)
+
+func replayConfig(api map[string]string) config.LeagueConfig {
+	cfg := config.LeagueConfig{
+		Type: "replay",
+		API: map[string]string{
+			"source":      "csv",
+			"team_column": "Home|Away",
+			"date_column": "Date",
+			"time_column": "Time",
+			"time_format": "3:04 PM",
+		},
+		Teams: []config.TeamEntry{{Key: "digdug", Name: "Dig Dug"}},
+	}
+	for k, v := range api {
+		cfg.API[k] = v
+	}
+	return cfg
+}
+
+func TestReplayFixtures(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "02.csv"), []byte(secondSheet), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "01.csv"), []byte(firstSheet), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".notes"), []byte("not a fixture"), 0o644))
+
+	lg, err := New("Rec League", replayConfig(map[string]string{"dir": dir}))
+	require.NoError(t, err)
+	assert.Equal(t, "rec-league", lg.Name())
+
+	var counts []int
+	for i := 0; i < 3; i++ {
+		result, err := lg.FetchAndParse(context.Background())
+		require.NoError(t, err)
+		counts = append(counts, len(result["digdug"]))
+	}
+	assert.Equal(t, []int{1, 2, 2}, counts, "steps through the files in name order, then repeats the last")
+	assert.Equal(t, secondSheet, lg.LastRawData())
+
+	result, err := lg.FetchAndParse(context.Background())
+	require.NoError(t, err)
+	game := result["digdug"][1]
+	assert.Equal(t, "rec-league", game.League)
+	assert.Equal(t, "Rec League", game.LeagueName, "emails show the configured name, not the namespace")
+	assert.Equal(t, "Block Party", game.Opponent)
+}
+
+func TestReplaySnapshots(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "copy.db")
+	db, err := storage.NewBoltStorage(dbPath)
+	require.NoError(t, err)
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, db.SaveSnapshot(models.Snapshot{ID: "snap-b", League: "rec", CSVData: secondSheet, FetchedAt: start.Add(2 * time.Hour)}))
+	require.NoError(t, db.SaveSnapshot(models.Snapshot{ID: "snap-a", League: "rec", CSVData: firstSheet, FetchedAt: start}))
+	require.NoError(t, db.SaveSnapshot(models.Snapshot{ID: "snap-c", League: "rec", Hash: "games only", FetchedAt: start.Add(time.Hour)}))
+	require.NoError(t, db.SaveSnapshot(models.Snapshot{ID: "snap-x", League: "rec-thursday", CSVData: "other", FetchedAt: start}))
+	require.NoError(t, db.Close())
+
+	lg, err := New("Rec League", replayConfig(map[string]string{"database": dbPath, "snapshots": "rec"}))
+	require.NoError(t, err)
+	require.Len(t, lg.steps, 2, "snapshots without raw data and other leagues' are skipped")
+	assert.Equal(t, firstSheet, lg.steps[0].raw)
+	assert.Equal(t, secondSheet, lg.steps[1].raw)
+
+	_, err = New("Rec League", replayConfig(map[string]string{"database": dbPath}))
+	assert.ErrorContains(t, err, "no snapshots of rec-league")
+}
+
+func TestReplayValidate(t *testing.T) {
+	problems := league.ValidateConfig("Rec League", replayConfig(nil))
+	assert.Contains(t, problems, "exactly one of api.dir and api.database is required for replay leagues")
+
+	problems = league.ValidateConfig("Rec League", replayConfig(map[string]string{"dir": "fixtures", "source": "replay"}))
+	assert.Equal(t, []string{`api.source: "replay" is not a league type that can be replayed`}, problems)
+
+	problems = league.ValidateConfig("Rec League", replayConfig(map[string]string{"dir": "fixtures", "date_format": "MM/DD/YYYY"}))
+	assert.Len(t, problems, 1, "the source type's own checks run too")
+
+	pinsConfig := config.LeagueConfig{
+		Type:  "replay",
+		API:   map[string]string{"source": "pins", "database": "copy.db"},
+		Teams: []config.TeamEntry{{Key: "ftm", Name: "French Toast Mafia", Day: "Wed"}},
+	}
+	problems = league.ValidateConfig("PINS", pinsConfig)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0], "pins snapshots are saved without the fetched pages")
+
+	_, err := New("PINS", pinsConfig)
+	assert.ErrorContains(t, err, "can't be replayed")
+}
@@ -0,0 +1,44 @@
+package league
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aweist/schedule-watcher/config"
+)
+
+// ValidateConfig is a config.LeagueValidator covering the settings that
+// depend on league types and notify modes.
+func ValidateConfig(name string, cfg config.LeagueConfig) []string {
+	var problems []string
+
+	switch cfg.NotifyMode {
+	case "", NotifyImmediate:
+	case NotifyDailyReminder:
+		if cfg.ReminderTime == "" {
+			problems = append(problems, fmt.Sprintf("reminder_time is required when notify_mode is %q", NotifyDailyReminder))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("notify_mode: %q is not one of %q, %q", cfg.NotifyMode, NotifyImmediate, NotifyDailyReminder))
+	}
+
+	problems = append(problems, validateMatchModes(cfg)...)
+
+	t, known := Lookup(cfg.Type)
+	if !known {
+		if cfg.Type != "" {
+			problems = append(problems, fmt.Sprintf("type: unknown league type %q (available: %s)", cfg.Type, strings.Join(typeNames(), ", ")))
+		}
+		return problems
+	}
+	for _, key := range t.RequiredAPI {
+		if cfg.API[key] == "" {
+			problems = append(problems, fmt.Sprintf("api.%s is required for %s leagues", key, t.Name))
+		}
+	}
+	if t.Validate != nil {
+		problems = append(problems, t.Validate(cfg)...)
+	}
+
+	return problems
+}
@@ -0,0 +1,79 @@
+package league
+
+import (
+	"testing"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.LeagueConfig
+		want []string
+	}{
+		{
+			name: "valid",
+			cfg: config.LeagueConfig{
+				Type: "test-secret",
+				API:  map[string]string{"instance": "x", "comp_id": "y"},
+			},
+		},
+		{
+			name: "missing required api keys",
+			cfg:  config.LeagueConfig{Type: "test-secret"},
+			want: []string{"api.instance is required for test-secret leagues", "api.comp_id is required for test-secret leagues"},
+		},
+		{
+			name: "type-specific validation",
+			cfg: config.LeagueConfig{
+				Type:  "test-daily",
+				API:   map[string]string{"base_url": "https://pins.example.com"},
+				Teams: []config.TeamEntry{{Key: "a", Name: "A"}},
+			},
+			want: []string{"teams[0].day is required"},
+		},
+		{
+			name: "unknown notify mode and type",
+			cfg:  config.LeagueConfig{Type: "bowling", NotifyMode: "hourly"},
+			want: []string{
+				`notify_mode: "hourly" is not one of "immediate", "daily_reminder"`,
+				`type: unknown league type "bowling" (available: test-daily, test-secret)`,
+			},
+		},
+		{
+			name: "daily reminder without time",
+			cfg: config.LeagueConfig{
+				Type:       "test-daily",
+				NotifyMode: NotifyDailyReminder,
+				API:        map[string]string{"base_url": "https://pins.example.com"},
+			},
+			want: []string{`reminder_time is required when notify_mode is "daily_reminder"`},
+		},
+		{
+			name: "match modes",
+			cfg: config.LeagueConfig{
+				Type: "test-secret",
+				API:  map[string]string{"instance": "x", "comp_id": "y"},
+				Teams: []config.TeamEntry{
+					{Key: "a", Name: "A", MatchMode: MatchExact},
+					{Key: "b", Name: "B", MatchMode: MatchNumber},
+					{Key: "c", Name: "C", MatchMode: "fuzzy"},
+					{Key: "d", Name: "D", MatchMode: MatchExact, Match: "^D$"},
+				},
+			},
+			want: []string{
+				`teams[1].team_number is required when match_mode is "number"`,
+				`teams[2].match_mode: "fuzzy" is not one of "substring", "exact", "number"`,
+				"teams[3]: match and match_mode can't both be set",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateConfig("L", tt.cfg))
+		})
+	}
+}
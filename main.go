@@ -1,24 +1,65 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"sort"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/aweist/schedule-watcher/client"
 	"github.com/aweist/schedule-watcher/config"
 	"github.com/aweist/schedule-watcher/league"
-	"github.com/aweist/schedule-watcher/league/ivp"
-	"github.com/aweist/schedule-watcher/league/pins"
+	"github.com/aweist/schedule-watcher/models"
 	"github.com/aweist/schedule-watcher/notifier"
 	"github.com/aweist/schedule-watcher/scheduler"
 	"github.com/aweist/schedule-watcher/storage"
 	"github.com/aweist/schedule-watcher/web"
+
+	// League implementations register their types in init.
+	_ "github.com/aweist/schedule-watcher/league/csvfeed"
+	_ "github.com/aweist/schedule-watcher/league/htmltable"
+	_ "github.com/aweist/schedule-watcher/league/ical"
+	_ "github.com/aweist/schedule-watcher/league/ivp"
+	_ "github.com/aweist/schedule-watcher/league/jsonapi"
+	_ "github.com/aweist/schedule-watcher/league/pins"
+	_ "github.com/aweist/schedule-watcher/league/replay"
 )
 
+// shutdownTimeout bounds how long shutdown waits for a poll, reminder or web
+// request in progress to finish before the database is closed.
+const shutdownTimeout = 30 * time.Second
+
 func main() {
-	cfg := config.Load()
-	log.Println("Configuration loaded")
+	configPath := flag.String("config", "", "path to the YAML or JSON config file (default $CONFIG_PATH or "+config.DefaultPath+")")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-config path] [discover [-league name] [-team text] [-all] [-snippet]]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	path := config.ResolvePath(*configPath)
+
+	if flag.Arg(0) == "discover" {
+		if err := runDiscover(path, flag.Args()[1:], os.Stdout); err != nil {
+			log.Fatalf("Discovery failed: %v", err)
+		}
+		return
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		log.Fatalf("Error loading configuration: %v", err)
+	}
+	if err := cfg.Validate(league.ValidateConfig); err != nil {
+		log.Fatalf("Refusing to start: %v", err)
+	}
+	log.Printf("Configuration loaded from %s", path)
+	configureFetcher(cfg)
 
 	db, err := storage.NewBoltStorage(cfg.Storage.DatabasePath)
 	if err != nil {
@@ -26,45 +67,13 @@ func main() {
 	}
 	defer db.Close()
 
-	// Migrate existing data to scoped keys if needed
-	for name, lg := range cfg.Leagues {
-		if len(lg.Teams) > 0 {
-			if err := db.MigrateToScoped(name, lg.Teams[0].Key); err != nil {
-				log.Printf("Warning: data migration failed: %v", err)
-			}
-			break // only migrate once using the first league
-		}
-	}
-
-	// Clean up DB data for league/team combos no longer in the config
-	// Storage keys use the league type (e.g., "pins", "ivp") as the first segment
-	validTeams := make(map[string]bool)
-	for _, lgCfg := range cfg.Leagues {
-		for _, t := range lgCfg.Teams {
-			validTeams[lgCfg.Type+":"+t.Key] = true
-		}
-	}
-	if err := db.CleanupStaleData(validTeams); err != nil {
-		log.Printf("Warning: stale data cleanup failed: %v", err)
-	}
+	migrateStorage(db, cfg)
 
-	// Build leagues
-	var leagues []league.League
-	for name, leagueConfig := range cfg.Leagues {
-		var lg league.League
-		switch leagueConfig.Type {
-		case "ivp":
-			lg, err = ivp.New(name, leagueConfig)
-		case "pins":
-			lg, err = pins.New(name, leagueConfig)
-		default:
-			log.Fatalf("Unknown league type %q for %q", leagueConfig.Type, name)
-		}
-		if err != nil {
-			log.Fatalf("Error building league %q: %v", name, err)
-		}
-		leagues = append(leagues, lg)
+	leagues, err := buildLeagues(cfg)
+	if err != nil {
+		log.Fatalf("%v", err)
 	}
+	migrateGameTimes(db, leagues)
 
 	// Set up notifier
 	var emailNotifier notifier.Notifier
@@ -81,12 +90,19 @@ func main() {
 		log.Println("WARNING: Email notifications disabled. Games will be tracked but no notifications will be sent.")
 	}
 
+	interval, _ := cfg.GetPollInterval()       // checked by Validate
+	leagueTimeout, _ := cfg.GetLeagueTimeout() // checked by Validate
+
 	// Create poller
 	poller := scheduler.NewPoller(scheduler.PollerConfig{
-		Leagues:  leagues,
-		Storage:  db,
-		Notifier: emailNotifier,
-		Interval: cfg.GetPollInterval(),
+		Leagues:       leagues,
+		Storage:       db,
+		Notifier:      emailNotifier,
+		Interval:      interval,
+		LeagueTimeout: leagueTimeout,
+		MaxConcurrent: cfg.Schedule.MaxConcurrent,
+		AlertEmail:    cfg.Alerts.Email,
+		AlertAfter:    cfg.Alerts.AfterFailures,
 	})
 
 	log.Printf("Starting Schedule Watcher with %d league(s)", len(leagues))
@@ -96,8 +112,30 @@ func main() {
 			log.Printf("    Team: %s (%s)", t.Name, t.Key)
 		}
 	}
-	log.Printf("Polling interval: %s", cfg.Schedule.PollInterval)
+	log.Printf("Polling interval: %s (up to %d leagues at once, %s each)", cfg.Schedule.PollInterval, cfg.Schedule.MaxConcurrent, cfg.Schedule.LeagueTimeout)
 	log.Printf("Database: %s", cfg.Storage.DatabasePath)
+	if cfg.Alerts.Email != "" {
+		log.Printf("Outage alerts: %s after %d failed polls", cfg.Alerts.Email, cfg.Alerts.AfterFailures)
+	}
+
+	// Daily reminder for leagues with notify_mode: daily_reminder
+	reminder := scheduler.NewDailyReminder(leagues, db, emailNotifier)
+
+	reload := &reloader{
+		path:     path,
+		db:       db,
+		cfg:      cfg,
+		poller:   poller,
+		reminder: reminder,
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	// Every long-running component stops when ctx is cancelled at shutdown.
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	var running sync.WaitGroup
 
 	// Start web server
 	if cfg.Web.Enabled {
@@ -105,18 +143,183 @@ func main() {
 		if emailNotifier != nil {
 			webServer.SetNotifier(emailNotifier)
 		}
-		go webServer.Start()
+		webServer.SetReloader(reload.Reload)
+		reload.web = webServer
+		run(ctx, &running, webServer.Start)
 	}
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-	go poller.Start()
+	run(ctx, &running, poller.Start)
+	run(ctx, &running, reminder.Start)
 
-	// Start daily reminder for leagues with notify_mode: daily_reminder
-	reminder := scheduler.NewDailyReminder(leagues, db, emailNotifier)
-	go reminder.Start()
+	for sig := range sigChan {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		log.Println("Received SIGHUP, reloading configuration...")
+		if _, err := reload.Reload(); err != nil {
+			log.Printf("Config reload failed, keeping current configuration: %v", err)
+		}
+	}
 
-	<-sigChan
 	log.Println("Shutting down Schedule Watcher...")
+	stop()
+	done := make(chan struct{})
+	go func() {
+		running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		log.Println("Shutdown complete")
+	case <-time.After(shutdownTimeout):
+		log.Printf("Shutdown timed out after %s, exiting with work in progress", shutdownTimeout)
+	}
+}
+
+// run starts component in a goroutine tracked by wg.
+func run(ctx context.Context, wg *sync.WaitGroup, component func(context.Context)) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		component(ctx)
+	}()
+}
+
+// migrateStorage brings the stored data in line with cfg: old unscoped and
+// per-type keys are moved to the leagues' namespaces, and data for leagues
+// and teams no longer configured is removed. It runs at startup and after
+// each reload.
+func migrateStorage(db *storage.BoltStorage, cfg *config.Config) {
+	// Migrate existing data to scoped keys if needed
+	for name, lg := range cfg.Leagues {
+		if len(lg.Teams) > 0 {
+			if err := db.MigrateToScoped(lg.StorageNamespace(name), lg.Teams[0].Key); err != nil {
+				log.Printf("Warning: data migration failed: %v", err)
+			}
+			break // only migrate once using the first league
+		}
+	}
+
+	migrateNamespaces(db, cfg)
+
+	// Clean up DB data for league/team combos no longer in the config
+	// Storage keys use the league's storage namespace as the first segment
+	validTeams := make(map[string]bool)
+	for name, lgCfg := range cfg.Leagues {
+		for _, t := range lgCfg.Teams {
+			validTeams[lgCfg.StorageNamespace(name)+":"+t.Key] = true
+		}
+	}
+	if err := db.CleanupStaleData(validTeams); err != nil {
+		log.Printf("Warning: stale data cleanup failed: %v", err)
+	}
+}
+
+// migrateNamespaces moves data stored before leagues had their own storage
+// namespaces. Keys used to start with the league type ("ivp", "pins"); when
+// exactly one league of a type is configured and its namespace differs from
+// the type, its old data is moved over so it survives the stale data cleanup.
+// With several leagues of one type, set namespace to the type on the league
+// that should keep the existing data.
+func migrateNamespaces(db *storage.BoltStorage, cfg *config.Config) {
+	byType := make(map[string][]string)
+	used := make(map[string]bool)
+	for name, lg := range cfg.Leagues {
+		ns := lg.StorageNamespace(name)
+		byType[lg.Type] = append(byType[lg.Type], ns)
+		used[ns] = true
+	}
+
+	for typ, namespaces := range byType {
+		if len(namespaces) != 1 || used[typ] {
+			continue
+		}
+		ns := namespaces[0]
+
+		hasOld, err := db.HasLeagueData(typ)
+		if err != nil || !hasOld {
+			continue
+		}
+		hasNew, err := db.HasLeagueData(ns)
+		if err != nil || hasNew {
+			continue
+		}
+
+		moved, err := db.RenameLeague(typ, ns)
+		if err != nil {
+			log.Printf("Warning: moving %s data to namespace %s failed: %v", typ, ns, err)
+			continue
+		}
+		log.Printf("Moved %d %s record(s) to storage namespace %s", moved, typ, ns)
+	}
+}
+
+// configureFetcher sets up the HTTP fetcher league clients share from the
+// config's http settings.
+func configureFetcher(cfg *config.Config) {
+	timeout, _ := cfg.GetHTTPTimeout() // checked by Validate; 0 picks the default
+	client.SetDefault(client.NewFetcher(client.FetcherConfig{
+		UserAgent: cfg.HTTP.UserAgent,
+		Timeout:   timeout,
+		Retries:   cfg.HTTP.Retries,
+	}))
+}
+
+// migrateGameTimes fills in the start and end of games stored before games
+// carried them, reading each game's time the way its league's parser does.
+func migrateGameTimes(db *storage.BoltStorage, leagues []league.League) {
+	byName := make(map[string]league.League)
+	for _, lg := range leagues {
+		byName[lg.Name()] = lg
+	}
+
+	updated, err := db.MigrateGameTimes(func(game *models.Game) bool {
+		lg, ok := byName[game.League]
+		if !ok {
+			return false
+		}
+		timer, ok := lg.(league.GameTimer)
+		if !ok {
+			return false
+		}
+		start, end, ok := timer.GameTimes(*game)
+		if !ok {
+			return false
+		}
+		game.StartsAt, game.EndsAt = start, end
+		if game.Timezone == "" {
+			game.Timezone = league.Location(lg).String()
+		}
+		return true
+	})
+	if err != nil {
+		log.Printf("Warning: filling in game start times failed: %v", err)
+		return
+	}
+	if updated > 0 {
+		log.Printf("Filled in start and end times for %d stored game(s)", updated)
+	}
+}
+
+// buildLeagues constructs a league for each configured entry, in name order
+// so polling and logs are consistent between runs. Per-host request limits
+// are reset first; the leagues built set them again from the new config.
+func buildLeagues(cfg *config.Config) ([]league.League, error) {
+	client.ResetHostLimits()
+
+	names := make([]string, 0, len(cfg.Leagues))
+	for name := range cfg.Leagues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var leagues []league.League
+	for _, name := range names {
+		lg, err := league.Build(name, cfg.Leagues[name])
+		if err != nil {
+			return nil, fmt.Errorf("building league %q: %w", name, err)
+		}
+		leagues = append(leagues, lg)
+	}
+	return leagues, nil
 }
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseClock returns the time s gives on date's calendar day, in loc. s is a
+// clock time such as "7:00 pm", "8PM", "19:30" or "9:40". Without an AM/PM
+// marker the hour is read on a 24-hour clock, unless assumePM is set, for
+// sources that leave it off because every game is in the evening: then hours
+// from 1 to 11 are pm. ok is false when s isn't a clock time.
+func ParseClock(date time.Time, s string, loc *time.Location, assumePM bool) (time.Time, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.ReplaceAll(s, ".", "")
+	s = strings.ReplaceAll(s, " ", "")
+
+	marker := ""
+	for _, m := range []string{"AM", "PM", "A", "P"} {
+		if strings.HasSuffix(s, m) {
+			marker = m[:1]
+			s = strings.TrimSuffix(s, m)
+			break
+		}
+	}
+
+	hourStr, minStr, hasMinutes := strings.Cut(s, ":")
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || hour < 0 || hour > 23 {
+		return time.Time{}, false
+	}
+	minute := 0
+	if hasMinutes {
+		minute, err = strconv.Atoi(minStr)
+		if err != nil || len(minStr) != 2 || minute > 59 {
+			return time.Time{}, false
+		}
+	}
+
+	switch {
+	case marker != "":
+		if hour < 1 || hour > 12 {
+			return time.Time{}, false
+		}
+		hour %= 12
+		if marker == "P" {
+			hour += 12
+		}
+	case assumePM && hour >= 1 && hour <= 11:
+		hour += 12
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, loc), true
+}
+
+// Start returns when the game starts: StartsAt, or the start of its day for
+// games with no known time. Use it to order games.
+func (g Game) Start() time.Time {
+	if !g.StartsAt.IsZero() {
+		return g.StartsAt
+	}
+	return g.Date
+}
+
+// Clock returns the game's start time for display ("7:00 PM"), falling back
+// to the source's Time text for games with no known start.
+func (g Game) Clock() string {
+	if g.StartsAt.IsZero() {
+		return g.Time
+	}
+	return g.StartsAt.In(g.Location()).Format("3:04 PM")
+}
@@ -1,21 +1,77 @@
 package models
 
 import (
+	"strings"
+	"sync"
 	"time"
 )
 
 type Game struct {
-	ID          string    `json:"id"`
-	League      string    `json:"league"`
-	TeamKey     string    `json:"team_key"`
-	TeamCaptain string    `json:"team_captain"`
-	TeamNumber  int       `json:"team_number"`
-	Division    string    `json:"division"`
-	Date        time.Time `json:"date"`
-	Time        string    `json:"time"`
-	Court       string    `json:"court"`
-	Opponent    string    `json:"opponent"`
-	Raw         string    `json:"raw"`
+	ID          string      `json:"id"`
+	League      string      `json:"league"`                // storage namespace
+	LeagueName  string      `json:"league_name,omitempty"` // display name, for emails and calendars
+	LeagueType  string      `json:"league_type,omitempty"`
+	TeamKey     string      `json:"team_key"`
+	TeamCaptain string      `json:"team_captain"`
+	TeamNumber  int         `json:"team_number"`
+	Division    string      `json:"division"`
+	Date        time.Time   `json:"date"`
+	Time        string      `json:"time"`               // start time as the source shows it
+	Timezone    string      `json:"timezone,omitempty"` // IANA zone of Date and Time; empty for local
+	Court       string      `json:"court"`
+	Opponent    string      `json:"opponent"`
+	Result      *GameResult `json:"result,omitempty"` // nil until scores are posted
+	Raw         string      `json:"raw"`
+
+	// StartsAt and EndsAt are when the game is played, or zero when the
+	// source gives no start time.
+	StartsAt time.Time `json:"starts_at,omitempty"`
+	EndsAt   time.Time `json:"ends_at,omitempty"`
+}
+
+// LeagueLabel returns the name to show for the game's league: its display
+// name, or for games stored before that was recorded, the namespace in
+// capitals.
+func (g Game) LeagueLabel() string {
+	return leagueLabel(g.LeagueName, g.League)
+}
+
+func leagueLabel(name, namespace string) string {
+	if name != "" {
+		return name
+	}
+	return strings.ToUpper(namespace)
+}
+
+// Location returns the time zone the game's date and time are given in.
+func (g Game) Location() *time.Location {
+	return LoadLocation(g.Timezone)
+}
+
+var locations sync.Map // zone name -> *time.Location
+
+// LoadLocation is time.LoadLocation, cached, falling back to the local time
+// zone for "" and names that don't load.
+func LoadLocation(name string) *time.Location {
+	if name == "" || name == "Local" {
+		return time.Local
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = time.Local
+	}
+	locations.Store(name, loc)
+	return loc
+}
+
+// GameResult is the outcome of a played game from our team's side.
+type GameResult struct {
+	GamesWon  int       `json:"games_won"`
+	GamesLost int       `json:"games_lost"`
+	PostedAt  time.Time `json:"posted_at,omitempty"` // when we first saw this result
 }
 
 type NotifiedGame struct {
@@ -51,3 +107,71 @@ type Snapshot struct {
 	Hash      string    `json:"hash"`
 	FetchedAt time.Time `json:"fetched_at"`
 }
+
+// Standing is one team's row in a league's standings table.
+type Standing struct {
+	League     string  `json:"league"`
+	TeamKey    string  `json:"team_key,omitempty"` // set for teams we track
+	TeamName   string  `json:"team_name"`
+	TeamNumber int     `json:"team_number"`
+	Division   string  `json:"division"`
+	Wins       int     `json:"wins"`
+	Losses     int     `json:"losses"`
+	WinPct     float64 `json:"win_pct"`
+	Rank       int     `json:"rank"` // position within the division, 1 = first
+}
+
+// StandingsSnapshot is a league's full standings as fetched at one time.
+// A new one is stored whenever the standings change, giving their history.
+type StandingsSnapshot struct {
+	League    string     `json:"league"`
+	Standings []Standing `json:"standings"`
+	Hash      string     `json:"hash"`
+	FetchedAt time.Time  `json:"fetched_at"`
+}
+
+// Season is one team's full schedule for a season that has been posted but
+// hasn't started yet.
+type Season struct {
+	League     string `json:"league"`
+	LeagueName string `json:"league_name,omitempty"`
+	TeamKey    string `json:"team_key"`
+	ScheduleID string `json:"schedule_id"`
+	Name       string `json:"name"` // e.g., "Tue Night Jun-Aug 2026 Season"
+	Games      []Game `json:"games"`
+}
+
+// LeagueLabel returns the name to show for the season's league, as
+// Game.LeagueLabel does.
+func (s Season) LeagueLabel() string {
+	return leagueLabel(s.LeagueName, s.League)
+}
+
+// SeasonAnnouncement records that a team's recipients were sent a season's
+// schedule, so each season is announced once.
+type SeasonAnnouncement struct {
+	League      string    `json:"league"`
+	TeamKey     string    `json:"team_key"`
+	ScheduleID  string    `json:"schedule_id"`
+	Name        string    `json:"name"`
+	GameCount   int       `json:"game_count"`
+	AnnouncedAt time.Time `json:"announced_at"`
+}
+
+// LeagueHealth tracks whether a league's source can be fetched, so a league
+// that keeps failing gets noticed.
+type LeagueHealth struct {
+	League              string    `json:"league"`
+	DisplayName         string    `json:"display_name"`
+	LastSuccess         time.Time `json:"last_success"`
+	LastFailure         time.Time `json:"last_failure"`
+	LastError           string    `json:"last_error,omitempty"` // kept after a recovery, for the record
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	FailingSince        time.Time `json:"failing_since"` // first failure of the current run
+	AlertSent           bool      `json:"alert_sent"`    // an outage alert went out for the current run
+}
+
+// Healthy reports whether the league's last poll succeeded.
+func (h LeagueHealth) Healthy() bool {
+	return h.ConsecutiveFailures == 0
+}
@@ -11,30 +11,63 @@ import (
 
 // GenerateICS creates an ICS (iCalendar) file content for a game
 func GenerateICS(game models.Game) string {
-	uid := fmt.Sprintf("%x@schedule-watcher", md5.Sum([]byte(game.ID)))
-
-	startTime, endTime := parseGameTime(game.Date, game.Time)
+	var ics strings.Builder
+	writeCalendarStart(&ics, game.LeagueLabel(), "REQUEST")
+	writeGameEvent(&ics, game)
+	ics.WriteString("END:VCALENDAR\r\n")
+	return ics.String()
+}
 
-	dtStart := startTime.UTC().Format("20060102T150405Z")
-	dtEnd := endTime.UTC().Format("20060102T150405Z")
-	dtStamp := time.Now().UTC().Format("20060102T150405Z")
+// GenerateSeasonICS creates an ICS file with an event for each game in a
+// season, for importing the whole season at once.
+func GenerateSeasonICS(season models.Season) string {
+	var ics strings.Builder
+	writeCalendarStart(&ics, season.LeagueLabel(), "PUBLISH")
+	ics.WriteString(fmt.Sprintf("X-WR-CALNAME:%s\r\n", escapeICS(season.Name)))
+	for _, game := range season.Games {
+		writeGameEvent(&ics, game)
+	}
+	ics.WriteString("END:VCALENDAR\r\n")
+	return ics.String()
+}
 
-	leagueName := strings.ToUpper(game.League)
+func writeCalendarStart(ics *strings.Builder, leagueName, method string) {
 	if leagueName == "" {
 		leagueName = "Volleyball"
 	}
 
-	var ics strings.Builder
 	ics.WriteString("BEGIN:VCALENDAR\r\n")
 	ics.WriteString("VERSION:2.0\r\n")
 	ics.WriteString(fmt.Sprintf("PRODID:-//%s Schedule Watcher//EN\r\n", leagueName))
 	ics.WriteString("CALSCALE:GREGORIAN\r\n")
-	ics.WriteString("METHOD:REQUEST\r\n")
+	ics.WriteString(fmt.Sprintf("METHOD:%s\r\n", method))
+}
+
+func writeGameEvent(ics *strings.Builder, game models.Game) {
+	uid := fmt.Sprintf("%x@schedule-watcher", md5.Sum([]byte(game.ID)))
+
+	dtStamp := time.Now().UTC().Format("20060102T150405Z")
+
+	leagueName := game.LeagueLabel()
+	if leagueName == "" {
+		leagueName = "Volleyball"
+	}
+
 	ics.WriteString("BEGIN:VEVENT\r\n")
 	ics.WriteString(fmt.Sprintf("UID:%s\r\n", uid))
 	ics.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", dtStamp))
-	ics.WriteString(fmt.Sprintf("DTSTART:%s\r\n", dtStart))
-	ics.WriteString(fmt.Sprintf("DTEND:%s\r\n", dtEnd))
+	if game.StartsAt.IsZero() {
+		// No start time: an all-day event on the game's date.
+		ics.WriteString(fmt.Sprintf("DTSTART;VALUE=DATE:%s\r\n", game.Date.Format("20060102")))
+		ics.WriteString(fmt.Sprintf("DTEND;VALUE=DATE:%s\r\n", game.Date.AddDate(0, 0, 1).Format("20060102")))
+	} else {
+		endsAt := game.EndsAt
+		if !endsAt.After(game.StartsAt) {
+			endsAt = game.StartsAt.Add(time.Hour)
+		}
+		ics.WriteString(fmt.Sprintf("DTSTART:%s\r\n", game.StartsAt.UTC().Format("20060102T150405Z")))
+		ics.WriteString(fmt.Sprintf("DTEND:%s\r\n", endsAt.UTC().Format("20060102T150405Z")))
+	}
 
 	summary := fmt.Sprintf("%s Volleyball Game", leagueName)
 	if game.Division != "" {
@@ -49,7 +82,7 @@ func GenerateICS(game models.Game) string {
 	if game.Division != "" {
 		description += fmt.Sprintf("\\nDivision: %s", game.Division)
 	}
-	description += fmt.Sprintf("\\nTime: %s\\nCourt: %s", game.Time, game.Court)
+	description += fmt.Sprintf("\\nTime: %s\\nCourt: %s", game.Clock(), game.Court)
 	if game.Opponent != "" {
 		description += fmt.Sprintf("\\nOpponent: %s", game.Opponent)
 	}
@@ -67,67 +100,6 @@ func GenerateICS(game models.Game) string {
 	ics.WriteString("END:VALARM\r\n")
 
 	ics.WriteString("END:VEVENT\r\n")
-	ics.WriteString("END:VCALENDAR\r\n")
-
-	return ics.String()
-}
-
-// parseGameTime converts the game date and time string into start and end times
-func parseGameTime(gameDate time.Time, timeStr string) (time.Time, time.Time) {
-	duration := time.Hour
-
-	timeStr = strings.TrimSpace(timeStr)
-	timeStr = strings.ToUpper(timeStr)
-	timeStr = strings.ReplaceAll(timeStr, ".", "")
-
-	// Extract the first time if multiple times are listed (e.g., "8/9pm" -> "8")
-	if strings.Contains(timeStr, "/") {
-		parts := strings.Split(timeStr, "/")
-		if len(parts) > 0 {
-			firstTime := parts[0]
-			if strings.Contains(strings.ToUpper(timeStr), "PM") && !strings.Contains(firstTime, "PM") {
-				timeStr = firstTime + "PM"
-			} else if strings.Contains(strings.ToUpper(timeStr), "AM") && !strings.Contains(firstTime, "AM") {
-				timeStr = firstTime + "AM"
-			} else {
-				timeStr = firstTime
-			}
-		}
-	}
-
-	var hour, minute int
-	var isPM bool
-
-	if strings.Contains(timeStr, ":") {
-		fmt.Sscanf(timeStr, "%d:%d", &hour, &minute)
-	} else {
-		fmt.Sscanf(timeStr, "%d", &hour)
-	}
-
-	if strings.Contains(timeStr, "PM") {
-		isPM = true
-	} else if strings.Contains(timeStr, "AM") {
-		isPM = false
-	} else {
-		if hour < 12 && hour != 0 {
-			isPM = true
-		}
-	}
-
-	if isPM && hour != 12 {
-		hour += 12
-	} else if !isPM && hour == 12 {
-		hour = 0
-	}
-
-	startTime := time.Date(
-		gameDate.Year(), gameDate.Month(), gameDate.Day(),
-		hour, minute, 0, 0, gameDate.Location(),
-	)
-
-	endTime := startTime.Add(duration)
-
-	return startTime, endTime
 }
 
 // escapeICS escapes special characters for ICS format
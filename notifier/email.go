@@ -48,15 +48,19 @@ func (e *EmailNotifier) SendNotification(game models.Game, recipients []string)
 		return fmt.Errorf("no email recipients provided")
 	}
 
-	leagueName := strings.ToUpper(game.League)
+	leagueName := game.LeagueLabel()
 	subject := fmt.Sprintf("[%s] New Volleyball Game Scheduled - %s", leagueName, game.Date.Format("Mon, Jan 2"))
+	if game.Opponent != "" {
+		subject += " vs " + game.Opponent
+	}
 	body, err := e.buildEmailBody(game)
 	if err != nil {
 		return fmt.Errorf("building email body: %w", err)
 	}
 
 	icsContent := GenerateICS(game)
-	message := e.buildMessageWithAttachment(subject, body, recipients, icsContent, game.Date.Format("2006-01-02"), leagueName)
+	filename := fmt.Sprintf("volleyball-game-%s.ics", game.Date.Format("2006-01-02"))
+	message := e.buildMessageWithAttachment(subject, body, recipients, icsContent, filename, "REQUEST", leagueName)
 
 	auth := smtp.PlainAuth("", e.username, e.password, e.smtpHost)
 	addr := fmt.Sprintf("%s:%s", e.smtpHost, e.smtpPort)
@@ -69,7 +73,123 @@ func (e *EmailNotifier) SendNotification(game models.Game, recipients []string)
 	return nil
 }
 
-func (e *EmailNotifier) buildMessageWithAttachment(subject, body string, recipients []string, icsContent string, dateStr string, leagueName string) string {
+func (e *EmailNotifier) SendResultNotification(game models.Game, recipients []string) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("no email recipients provided")
+	}
+	if game.Result == nil {
+		return fmt.Errorf("game %s has no result", game.ID)
+	}
+
+	leagueName := game.LeagueLabel()
+	subject := fmt.Sprintf("[%s] Result Posted - %s: %s", leagueName, game.Date.Format("Mon, Jan 2"), resultSummary(game))
+	body, err := e.buildResultEmailBody(game)
+	if err != nil {
+		return fmt.Errorf("building email body: %w", err)
+	}
+
+	message := e.buildMessage(subject, body, recipients, leagueName)
+
+	auth := smtp.PlainAuth("", e.username, e.password, e.smtpHost)
+	addr := fmt.Sprintf("%s:%s", e.smtpHost, e.smtpPort)
+
+	if err := smtp.SendMail(addr, auth, e.from, recipients, []byte(message)); err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+
+	return nil
+}
+
+func (e *EmailNotifier) SendSeasonNotification(season models.Season, recipients []string) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("no email recipients provided")
+	}
+
+	leagueName := season.LeagueLabel()
+	subject := fmt.Sprintf("[%s] New Season Schedule Posted - %s", leagueName, season.Name)
+	body, err := e.buildSeasonEmailBody(season)
+	if err != nil {
+		return fmt.Errorf("building email body: %w", err)
+	}
+
+	icsContent := GenerateSeasonICS(season)
+	filename := fmt.Sprintf("volleyball-season-%s.ics", season.ScheduleID)
+	message := e.buildMessageWithAttachment(subject, body, recipients, icsContent, filename, "PUBLISH", leagueName)
+
+	auth := smtp.PlainAuth("", e.username, e.password, e.smtpHost)
+	addr := fmt.Sprintf("%s:%s", e.smtpHost, e.smtpPort)
+
+	if err := smtp.SendMail(addr, auth, e.from, recipients, []byte(message)); err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+
+	return nil
+}
+
+func (e *EmailNotifier) SendOutageAlert(health models.LeagueHealth, recipients []string) error {
+	subject := fmt.Sprintf("[Schedule Watcher] %s is failing (%d polls in a row)", health.DisplayName, health.ConsecutiveFailures)
+	return e.sendHealthAlert(subject, health, false, recipients)
+}
+
+func (e *EmailNotifier) SendRecoveryAlert(health models.LeagueHealth, recipients []string) error {
+	subject := fmt.Sprintf("[Schedule Watcher] %s has recovered", health.DisplayName)
+	return e.sendHealthAlert(subject, health, true, recipients)
+}
+
+// sendHealthAlert sends an outage or recovery alert about a league's source.
+func (e *EmailNotifier) sendHealthAlert(subject string, health models.LeagueHealth, recovered bool, recipients []string) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("no email recipients provided")
+	}
+
+	body, err := e.buildHealthEmailBody(health, recovered)
+	if err != nil {
+		return fmt.Errorf("building email body: %w", err)
+	}
+
+	message := e.buildMessage(subject, body, recipients, "Schedule Watcher")
+
+	auth := smtp.PlainAuth("", e.username, e.password, e.smtpHost)
+	addr := fmt.Sprintf("%s:%s", e.smtpHost, e.smtpPort)
+
+	if err := smtp.SendMail(addr, auth, e.from, recipients, []byte(message)); err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+
+	return nil
+}
+
+// resultSummary describes a game's result as "Won 2-1 vs Opponent".
+func resultSummary(game models.Game) string {
+	r := game.Result
+	outcome := "Tied"
+	switch {
+	case r.GamesWon > r.GamesLost:
+		outcome = "Won"
+	case r.GamesWon < r.GamesLost:
+		outcome = "Lost"
+	}
+	summary := fmt.Sprintf("%s %d-%d", outcome, r.GamesWon, r.GamesLost)
+	if game.Opponent != "" {
+		summary += " vs " + game.Opponent
+	}
+	return summary
+}
+
+// buildMessage builds a plain HTML email with no attachments.
+func (e *EmailNotifier) buildMessage(subject, body string, recipients []string, leagueName string) string {
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("From: %s Game Alerts <%s>\r\n", leagueName, e.from))
+	message.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(recipients, ", ")))
+	message.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(body)
+	return message.String()
+}
+
+func (e *EmailNotifier) buildMessageWithAttachment(subject, body string, recipients []string, icsContent string, filename string, method string, leagueName string) string {
 	var buf bytes.Buffer
 	writer := multipart.NewWriter(&buf)
 
@@ -92,9 +212,9 @@ func (e *EmailNotifier) buildMessageWithAttachment(subject, body string, recipie
 	htmlPart.Write([]byte(body))
 
 	icsPart, _ := writer.CreatePart(textproto.MIMEHeader{
-		"Content-Type":              []string{"text/calendar; charset=UTF-8; method=REQUEST"},
+		"Content-Type":              []string{fmt.Sprintf("text/calendar; charset=UTF-8; method=%s", method)},
 		"Content-Transfer-Encoding": []string{"base64"},
-		"Content-Disposition":       []string{fmt.Sprintf("attachment; filename=\"volleyball-game-%s.ics\"", dateStr)},
+		"Content-Disposition":       []string{fmt.Sprintf("attachment; filename=\"%s\"", filename)},
 	})
 
 	encoded := base64.StdEncoding.EncodeToString([]byte(icsContent))
@@ -114,8 +234,8 @@ func (e *EmailNotifier) buildMessageWithAttachment(subject, body string, recipie
 }
 
 func (e *EmailNotifier) buildEmailBody(game models.Game) (string, error) {
-	leagueName := strings.ToUpper(game.League)
-	scheduleLink := getScheduleLink(game.League)
+	leagueName := game.LeagueLabel()
+	scheduleLink := getScheduleLink(game.LeagueType)
 
 	tmplStr := `
 <!DOCTYPE html>
@@ -257,7 +377,7 @@ func (e *EmailNotifier) buildEmailBody(game models.Game) (string, error) {
 	}{
 		LeagueName:   leagueName,
 		Date:         game.Date.Format("Monday, January 2, 2006"),
-		Time:         game.Time,
+		Time:         game.Clock(),
 		Court:        game.Court,
 		Division:     game.Division,
 		TeamCaptain:  game.TeamCaptain,
@@ -275,8 +395,150 @@ func (e *EmailNotifier) buildEmailBody(game models.Game) (string, error) {
 	return buf.String(), nil
 }
 
-func getScheduleLink(league string) string {
-	switch strings.ToLower(league) {
+func (e *EmailNotifier) buildResultEmailBody(game models.Game) (string, error) {
+	tmplStr := `
+<!DOCTYPE html>
+<html>
+<head>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #2c3e50; color: white; padding: 20px; text-align: center; border-radius: 5px 5px 0 0; }
+        .content { background-color: #f4f4f4; padding: 20px; border-radius: 0 0 5px 5px; }
+        .score { font-size: 32px; font-weight: bold; text-align: center; margin: 10px 0 20px; }
+        .detail-row { margin: 10px 0; }
+        .label { font-weight: bold; color: #2c3e50; }
+        .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.LeagueName}} Result Posted</h1>
+        </div>
+        <div class="content">
+            <div class="score">{{.Summary}}</div>
+            <div class="detail-row">
+                <span class="label">Date:</span> {{.Date}} at {{.Time}}
+            </div>
+            <div class="detail-row">
+                <span class="label">Court:</span> {{.Court}}
+            </div>
+            <div class="detail-row">
+                <span class="label">Team:</span> {{.TeamCaptain}}
+            </div>
+            <div class="footer">
+                <p>This is an automated notification from the {{.LeagueName}} Schedule Watcher</p>
+            </div>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	tmpl, err := template.New("result").Parse(tmplStr)
+	if err != nil {
+		return "", err
+	}
+
+	data := struct {
+		LeagueName  string
+		Summary     string
+		Date        string
+		Time        string
+		Court       string
+		TeamCaptain string
+	}{
+		LeagueName:  game.LeagueLabel(),
+		Summary:     resultSummary(game),
+		Date:        game.Date.Format("Monday, January 2, 2006"),
+		Time:        game.Clock(),
+		Court:       game.Court,
+		TeamCaptain: game.TeamCaptain,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (e *EmailNotifier) buildSeasonEmailBody(season models.Season) (string, error) {
+	tmplStr := `
+<!DOCTYPE html>
+<html>
+<head>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #2c3e50; color: white; padding: 20px; text-align: center; border-radius: 5px 5px 0 0; }
+        .content { background-color: #f4f4f4; padding: 20px; border-radius: 0 0 5px 5px; }
+        table { width: 100%; border-collapse: collapse; background-color: white; }
+        th, td { padding: 8px; text-align: left; border-bottom: 1px solid #ddd; }
+        th { color: #2c3e50; }
+        .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>New {{.LeagueName}} Season Posted!</h1>
+        </div>
+        <div class="content">
+            <p><strong>{{.TeamCaptain}}</strong> is on the {{.SeasonName}} schedule{{if .Division}} ({{.Division}}){{end}}.</p>
+            <table>
+                <tr><th>Date</th><th>Time</th><th>Court</th><th>Opponent</th></tr>
+                {{range .Games}}
+                <tr><td>{{.Date.Format "Mon, Jan 2"}}</td><td>{{.Clock}}</td><td>{{.Court}}</td><td>{{.Opponent}}</td></tr>
+                {{end}}
+            </table>
+
+            <p style="text-align: center; margin: 20px 0;">
+                <em>A calendar file with every game is attached to this email</em>
+            </p>
+
+            <div class="footer">
+                <p>This is an automated notification from the {{.LeagueName}} Schedule Watcher</p>
+            </div>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	tmpl, err := template.New("season").Parse(tmplStr)
+	if err != nil {
+		return "", err
+	}
+
+	data := struct {
+		LeagueName  string
+		SeasonName  string
+		TeamCaptain string
+		Division    string
+		Games       []models.Game
+	}{
+		LeagueName: season.LeagueLabel(),
+		SeasonName: season.Name,
+		Games:      season.Games,
+	}
+	if len(season.Games) > 0 {
+		data.TeamCaptain = season.Games[0].TeamCaptain
+		data.Division = season.Games[0].Division
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func getScheduleLink(leagueType string) string {
+	switch strings.ToLower(leagueType) {
 	case "ivp":
 		return "https://winlossdraw.com/ivp"
 	case "pins":
@@ -285,3 +547,84 @@ func getScheduleLink(league string) string {
 		return ""
 	}
 }
+
+func (e *EmailNotifier) buildHealthEmailBody(health models.LeagueHealth, recovered bool) (string, error) {
+	tmplStr := `
+<!DOCTYPE html>
+<html>
+<head>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { color: white; padding: 20px; text-align: center; border-radius: 5px 5px 0 0; }
+        .down { background-color: #c0392b; }
+        .up { background-color: #27ae60; }
+        .content { background-color: #f4f4f4; padding: 20px; border-radius: 0 0 5px 5px; }
+        .detail-row { margin: 10px 0; }
+        .label { font-weight: bold; color: #2c3e50; }
+        .error { font-family: monospace; white-space: pre-wrap; background-color: white; padding: 10px; }
+        .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        {{if .Recovered}}
+        <div class="header up">
+            <h1>{{.Name}} Recovered</h1>
+        </div>
+        {{else}}
+        <div class="header down">
+            <h1>{{.Name}} Is Failing</h1>
+        </div>
+        {{end}}
+        <div class="content">
+            {{if .Recovered}}
+            <p>{{.Name}} was fetched successfully at {{.LastSuccess}} after {{.Failures}} failed poll(s).</p>
+            {{else}}
+            <p>{{.Name}} has failed {{.Failures}} poll(s) in a row. Schedule changes won't be noticed until it recovers.</p>
+            {{end}}
+            <div class="detail-row">
+                <span class="label">Failing since:</span> {{.FailingSince}}
+            </div>
+            <div class="detail-row">
+                <span class="label">Last error:</span>
+                <div class="error">{{.LastError}}</div>
+            </div>
+            <div class="footer">
+                <p>This is an automated alert from the Schedule Watcher</p>
+            </div>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	tmpl, err := template.New("health").Parse(tmplStr)
+	if err != nil {
+		return "", err
+	}
+
+	const stamp = "Mon, Jan 2 3:04 PM MST"
+	data := struct {
+		Name         string
+		Recovered    bool
+		Failures     int
+		FailingSince string
+		LastSuccess  string
+		LastError    string
+	}{
+		Name:         health.DisplayName,
+		Recovered:    recovered,
+		Failures:     health.ConsecutiveFailures,
+		FailingSince: health.FailingSince.Format(stamp),
+		LastSuccess:  health.LastSuccess.Format(stamp),
+		LastError:    health.LastError,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
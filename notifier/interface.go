@@ -6,5 +6,18 @@ import (
 
 type Notifier interface {
 	SendNotification(game models.Game, recipients []string) error
+	// SendResultNotification tells recipients a game's result (game.Result)
+	// has been posted.
+	SendResultNotification(game models.Game, recipients []string) error
+	// SendSeasonNotification sends recipients a newly posted season's full
+	// schedule.
+	SendSeasonNotification(season models.Season, recipients []string) error
+	// SendOutageAlert tells the admin a league's source has failed
+	// health.ConsecutiveFailures polls in a row.
+	SendOutageAlert(health models.LeagueHealth, recipients []string) error
+	// SendRecoveryAlert tells the admin a league whose outage they were
+	// alerted to is fetching again. health is the record as it stood before
+	// the successful poll, with LastSuccess set to it.
+	SendRecoveryAlert(health models.LeagueHealth, recipients []string) error
 	GetType() string
 }
@@ -16,20 +16,60 @@ type columnMap struct {
 	captain  int
 	teamNum  int
 	division int
+	winPct   int
+	wins     int
+	losses   int
 }
 
+// TeamFilter reports whether a schedule row, given by its captain, team
+// number (0 if missing) and division, is a team of interest.
+type TeamFilter func(captain string, teamNum int, division string) bool
+
 type CSVParser struct {
 	teamName string
-	year     int
+	match    TeamFilter
+	loc      *time.Location
+	now      time.Time
+	warnings []string
+	matched  []Team
 }
 
+// NewCSVParser picks out the rows whose captain contains teamName, ignoring
+// case.
 func NewCSVParser(teamName string) *CSVParser {
-	return &CSVParser{
+	p := &CSVParser{
 		teamName: teamName,
-		year:     time.Now().Year(),
+		loc:      time.Local,
+		now:      time.Now(),
+	}
+	p.match = func(captain string, _ int, _ string) bool {
+		return p.isTeamOfInterest(captain)
+	}
+	return p
+}
+
+// NewCSVParserMatching picks out the rows match accepts, dating games in
+// loc.
+func NewCSVParserMatching(match TeamFilter, loc *time.Location) *CSVParser {
+	return &CSVParser{
+		match: match,
+		loc:   loc,
+		now:   time.Now().In(loc),
 	}
 }
 
+// MatchedTeams returns the rows picked out by the last ParseSchedule, in
+// order. More than one usually means the team's match is too loose.
+func (p *CSVParser) MatchedTeams() []Team {
+	return p.matched
+}
+
+// Warnings returns problems found by the last ParseSchedule that didn't stop
+// it, such as date headers that couldn't be parsed.
+func (p *CSVParser) Warnings() []string {
+	return p.warnings
+}
+
 func (p *CSVParser) ParseSchedule(csvData string) ([]models.Game, error) {
 	reader := csv.NewReader(strings.NewReader(csvData))
 	records, err := reader.ReadAll()
@@ -44,9 +84,17 @@ func (p *CSVParser) ParseSchedule(csvData string) ([]models.Game, error) {
 	var games []models.Game
 	headers := records[0]
 
-	colMap := p.buildColumnMap(headers)
+	colMap := buildColumnMap(headers)
 	dateColumns := p.findDateColumns(headers)
-
+	dates, problems := p.resolveDates(headers, dateColumns)
+	p.warnings = problems
+	p.matched = nil
+
+	// Index every team's games by slot first; teams sharing a date, time
+	// and court are playing each other. Without a court there's no telling
+	// who plays whom, so those slots aren't indexed.
+	captains := make(map[int]string)
+	bySlot := make(map[slot][]int)
 	for i := 1; i < len(records); i++ {
 		row := records[i]
 		if len(row) < 7 {
@@ -57,36 +105,89 @@ func (p *CSVParser) ParseSchedule(csvData string) ([]models.Game, error) {
 		if teamCaptain == "" || strings.Contains(teamCaptain, "Fall Schedule") {
 			continue
 		}
+		captains[i] = teamCaptain
+
+		for _, sl := range rowSlots(row, dateColumns) {
+			if sl.court == "" {
+				continue
+			}
+			bySlot[sl] = append(bySlot[sl], i)
+		}
+	}
 
-		if !p.isTeamOfInterest(teamCaptain) {
+	for i := 1; i < len(records); i++ {
+		teamCaptain, ok := captains[i]
+		if !ok {
 			continue
 		}
+		row := records[i]
 
-		teamNum, _ := strconv.Atoi(row[colMap.teamNum])
-		division := strings.TrimSpace(row[colMap.division])
+		teamNum, _ := strconv.Atoi(cell(row, colMap.teamNum))
+		division := cell(row, colMap.division)
+		if !p.match(teamCaptain, teamNum, division) {
+			continue
+		}
+		p.matched = append(p.matched, Team{Captain: teamCaptain, Number: teamNum, Division: division})
 
-		for _, colIdx := range dateColumns {
-			if colIdx > 0 && colIdx < len(row) {
-				// We need to account for multiple games per night.
-				// Normally this is in the format of 8/9pm,ct 7/7
-				gameTimes := gameTimeStrToGameTimes(row[colIdx-1]) // Time is in column before date
-				courts := courtStrToCourts(row[colIdx])            // Court is in the date column
+		for _, sl := range rowSlots(row, dateColumns) {
+			date, ok := dates[sl.dateCol]
+			if !ok {
+				continue // reported by resolveDates
+			}
+			game := p.createGame(teamCaptain, teamNum, division, sl.time, sl.court, date, headers[sl.dateCol])
 
-				for i, gameTime := range gameTimes {
-					court := courts[0]
-					if i < len(courts) {
-						court = courts[i]
-					}
-					game := p.createGame(teamCaptain, teamNum, division, gameTime, court, headers[colIdx])
-					games = append(games, game)
+			var opponents []string
+			for _, other := range bySlot[sl] {
+				if other != i {
+					opponents = append(opponents, captains[other])
 				}
 			}
+			game.Opponent = strings.Join(opponents, " / ")
+
+			games = append(games, game)
 		}
 	}
 
 	return games, nil
 }
 
+// slot is one scheduled game: the date column, time and court.
+type slot struct {
+	dateCol int
+	time    string
+	court   string
+}
+
+// rowSlots lists the games in a row in date column order, so games come out
+// chronologically. A double-header such as "8/9pm" with "ct 7/7" yields one
+// slot per game.
+func rowSlots(row []string, dateColumns map[int]int) []slot {
+	var slots []slot
+	for d := 0; d < len(dateColumns); d++ {
+		colIdx := dateColumns[d]
+		if colIdx <= 0 || colIdx >= len(row) {
+			continue
+		}
+
+		// We need to account for multiple games per night.
+		// Normally this is in the format of 8/9pm,ct 7/7
+		gameTimes := gameTimeStrToGameTimes(row[colIdx-1]) // Time is in column before date
+		courts := courtStrToCourts(row[colIdx])            // Court is in the date column
+		if len(courts) == 0 {
+			courts = []string{""}
+		}
+
+		for i, gameTime := range gameTimes {
+			court := courts[0]
+			if i < len(courts) {
+				court = courts[i]
+			}
+			slots = append(slots, slot{dateCol: colIdx, time: gameTime, court: court})
+		}
+	}
+	return slots
+}
+
 func courtStrToCourts(s string) []string {
 	courts := []string{}
 	s = strings.ToLower(s)
@@ -122,11 +223,14 @@ func gameTimeStrToGameTimes(s string) []string {
 	return gameTimes
 }
 
-func (p *CSVParser) buildColumnMap(headers []string) columnMap {
+func buildColumnMap(headers []string) columnMap {
 	cm := columnMap{
 		captain:  0, // default to first column
 		teamNum:  -1,
 		division: -1,
+		winPct:   -1,
+		wins:     -1,
+		losses:   -1,
 	}
 
 	for i, header := range headers {
@@ -138,6 +242,12 @@ func (p *CSVParser) buildColumnMap(headers []string) columnMap {
 			cm.teamNum = i
 		case strings.Contains(normalized, "division"):
 			cm.division = i
+		case strings.HasPrefix(normalized, "win") && strings.Contains(normalized, "%"):
+			cm.winPct = i
+		case normalized == "wins" || normalized == "win" || normalized == "w":
+			cm.wins = i
+		case normalized == "loss" || normalized == "losses" || normalized == "l":
+			cm.losses = i
 		}
 	}
 
@@ -166,10 +276,9 @@ func (p *CSVParser) isTeamOfInterest(captain string) bool {
 	return strings.Contains(captainLower, teamLower)
 }
 
-func (p *CSVParser) createGame(captain string, teamNum int, division string, gameTime, court, dateStr string) models.Game {
-	gameDate := p.parseDate(dateStr)
-
+func (p *CSVParser) createGame(captain string, teamNum int, division string, gameTime, court string, gameDate time.Time, dateStr string) models.Game {
 	gameID := p.generateGameID(captain, gameDate, gameTime, court)
+	startsAt, _ := GameStart(gameDate, gameTime, p.loc)
 
 	return models.Game{
 		ID:          gameID,
@@ -180,36 +289,158 @@ func (p *CSVParser) createGame(captain string, teamNum int, division string, gam
 		Time:        gameTime,
 		Court:       court,
 		Raw:         fmt.Sprintf("%s|%s|%s", dateStr, gameTime, court),
+		StartsAt:    startsAt,
 	}
 }
 
-func (p *CSVParser) parseDate(dateStr string) time.Time {
+// GameStart returns when a game at gameTime (e.g. "7:00 pm") on date
+// starts. IVP plays evenings and its sheets leave off the pm, so times
+// without one are pm.
+func GameStart(date time.Time, gameTime string, loc *time.Location) (time.Time, bool) {
+	return models.ParseClock(date, gameTime, loc, true)
+}
+
+// dateHeader is a date column header split into its parts. year is 0 when
+// the header leaves it out, as in "04/09".
+type dateHeader struct {
+	month, day, year int
+}
+
+// parseDateHeader parses a "M/D" or "M/D/YYYY" (or "M/D/YY") date header.
+func parseDateHeader(dateStr string) (dateHeader, bool) {
 	dateStr = strings.TrimSpace(dateStr)
 	dateStr = strings.ReplaceAll(dateStr, "time,", "")
 	dateStr = strings.ReplaceAll(dateStr, "Time,", "")
 	dateStr = strings.TrimSpace(dateStr)
 
 	parts := strings.Split(dateStr, "/")
-	if len(parts) >= 2 {
-		month, _ := strconv.Atoi(parts[0])
-		day, _ := strconv.Atoi(parts[1])
-
-		year := p.year
-		if len(parts) == 3 {
-			yearPart, _ := strconv.Atoi(parts[2])
-			if yearPart > 0 {
-				if yearPart < 100 {
-					year = 2000 + yearPart
-				} else {
-					year = yearPart
-				}
+	if len(parts) != 2 && len(parts) != 3 {
+		return dateHeader{}, false
+	}
+
+	month, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || month < 1 || month > 12 {
+		return dateHeader{}, false
+	}
+	day, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil || day < 1 {
+		return dateHeader{}, false
+	}
+
+	h := dateHeader{month: month, day: day}
+	if len(parts) == 3 {
+		year, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err != nil || year <= 0 {
+			return dateHeader{}, false
+		}
+		if year < 100 {
+			year += 2000
+		}
+		h.year = year
+	}
+
+	// Reject days past the end of the month; Feb 29 is checked once the
+	// year is known.
+	if day > daysIn(time.Month(month), 2024) {
+		return dateHeader{}, false
+	}
+	return h, true
+}
+
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// resolveDates gives each date column a full date. Headers usually leave out
+// the year, so it is taken from the first header that has one (e.g.,
+// "4/2/2026") and carried across the other columns, moving to the next year
+// where the months wrap around (a fall season running into January) and to
+// the previous year going back from it. With no full date at all, the first
+// column gets whichever year puts it closest to now. Headers that aren't
+// valid dates are left out and reported, rather than guessed at.
+func (p *CSVParser) resolveDates(headers []string, dateColumns map[int]int) (map[int]time.Time, []string) {
+	type column struct {
+		idx    int
+		header dateHeader
+	}
+
+	var problems []string
+	var cols []column
+	for d := 0; d < len(dateColumns); d++ {
+		colIdx := dateColumns[d]
+		h, ok := parseDateHeader(headers[colIdx])
+		if !ok {
+			problems = append(problems, fmt.Sprintf("column %d: can't parse date %q", colIdx+1, headers[colIdx]))
+			continue
+		}
+		cols = append(cols, column{idx: colIdx, header: h})
+	}
+
+	dates := make(map[int]time.Time)
+	if len(cols) == 0 {
+		return dates, problems
+	}
+
+	anchor := 0
+	for i, c := range cols {
+		if c.header.year != 0 {
+			anchor = i
+			break
+		}
+	}
+	years := make([]int, len(cols))
+	years[anchor] = cols[anchor].header.year
+	if years[anchor] == 0 {
+		h := cols[anchor].header
+		years[anchor] = ClosestYear(time.Month(h.month), h.day, p.now.In(p.loc))
+	}
+
+	// A month more than half a year before the previous column's is taken
+	// to be in the next year, so one column out of order doesn't skip a year.
+	for i := anchor + 1; i < len(cols); i++ {
+		years[i] = cols[i].header.year
+		if years[i] == 0 {
+			years[i] = years[i-1]
+			if cols[i].header.month+6 <= cols[i-1].header.month {
+				years[i]++
 			}
 		}
+	}
+	for i := anchor - 1; i >= 0; i-- {
+		years[i] = years[i+1]
+		if cols[i].header.month >= cols[i+1].header.month+6 {
+			years[i]--
+		}
+	}
 
-		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+	for i, c := range cols {
+		if c.header.day > daysIn(time.Month(c.header.month), years[i]) {
+			problems = append(problems, fmt.Sprintf("column %d: %q is not a date in %d", c.idx+1, headers[c.idx], years[i]))
+			continue
+		}
+		dates[c.idx] = time.Date(years[i], time.Month(c.header.month), c.header.day, 0, 0, 0, 0, p.loc)
 	}
+	return dates, problems
+}
 
-	return time.Time{}
+// ClosestYear picks the year, around now's, that puts month and day nearest
+// now, for dates listed without one.
+func ClosestYear(month time.Month, day int, now time.Time) int {
+	best := now.Year()
+	var bestDist time.Duration = -1
+	for year := now.Year() - 1; year <= now.Year()+1; year++ {
+		if day > daysIn(month, year) {
+			continue
+		}
+		dist := time.Date(year, month, day, 0, 0, 0, 0, now.Location()).Sub(now)
+		if dist < 0 {
+			dist = -dist
+		}
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = year, dist
+		}
+	}
+	return best
 }
 
 func (p *CSVParser) generateGameID(captain string, date time.Time, gameTime, court string) string {
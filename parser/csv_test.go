@@ -190,50 +190,296 @@ Jeff,1,66.67%,Comp Div 1 AG,4,2,7:00 PM,ct 7,,7:00 PM,ct 7,,7:00 PM,ct 7`
 	}
 }
 
-func TestCSVParser_ParseDate(t *testing.T) {
-	parser := NewCSVParser("test")
-	currentYear := time.Now().Year()
+func TestCSVParser_ParseScheduleOpponents(t *testing.T) {
+	csvData := `Team Captain ,Team #,Win %,Division ,Wins,Loss,time,8/21/2025,,time,08/28
+Jeff,1,66.67%,Comp Div 1 AG,4,2,7:00 PM,ct 7,,8/9pm,ct 7/7
+Ann,2,50.00%,Comp Div 1 AG,3,3,7:00 PM,ct 7,,8:00 PM,ct 7
+Bob,3,50.00%,Comp Div 1 AG,3,3,7:00 PM,ct 8,,9:00 PM,ct 7
+Cat,4,0.00%,Comp Div 1 AG,0,6,7:00 PM,ct 8,,,`
+
+	parser := NewCSVParser("Jeff")
+	games, err := parser.ParseSchedule(csvData)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	if len(games) != 3 {
+		t.Fatalf("Expected 3 games, got %d", len(games))
+	}
+
+	// Same date, time and court is the other team in the game; each half
+	// of the double-header has its own opponent.
+	expected := []struct {
+		time, court, opponent string
+	}{
+		{"7:00 pm", "7", "Ann"},
+		{"8:00 pm", "7", "Ann"},
+		{"9:00 pm", "7", "Bob"},
+	}
+	for i, want := range expected {
+		game := games[i]
+		if game.Time != want.time || game.Court != want.court {
+			t.Errorf("Game %d: expected %s on court %s, got %s on court %s", i, want.time, want.court, game.Time, game.Court)
+		}
+		if game.Opponent != want.opponent {
+			t.Errorf("Game %d: expected opponent '%s', got '%s'", i, want.opponent, game.Opponent)
+		}
+	}
+}
+
+func TestCSVParser_ParseScheduleNoOpponent(t *testing.T) {
+	csvData := `Team Captain ,Team #,Win %,Division ,Wins,Loss,time,8/21/2025
+Jeff,1,66.67%,Comp Div 1 AG,4,2,7:00 PM,ct 7
+Ann,2,50.00%,Comp Div 1 AG,3,3,7:00 PM,ct 8`
+
+	parser := NewCSVParser("Jeff")
+	games, err := parser.ParseSchedule(csvData)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	if len(games) != 1 {
+		t.Fatalf("Expected 1 game, got %d", len(games))
+	}
+	if games[0].Opponent != "" {
+		t.Errorf("Expected no opponent, got '%s'", games[0].Opponent)
+	}
+}
+
+func TestCSVParser_ParseScheduleNoCourtNoOpponent(t *testing.T) {
+	csvData := `Team Captain ,Team #,Win %,Division ,Wins,Loss,time,8/21/2025
+Jeff,1,66.67%,Comp Div 1 AG,4,2,7:00 PM,
+Ann,2,50.00%,Comp Div 1 AG,3,3,7:00 PM,
+Bob,3,50.00%,Comp Div 1 AG,3,3,7:00 PM,`
+
+	parser := NewCSVParser("Jeff")
+	games, err := parser.ParseSchedule(csvData)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	// Teams playing at the same time without courts listed aren't
+	// necessarily playing each other.
+	if len(games) != 1 {
+		t.Fatalf("Expected 1 game, got %d", len(games))
+	}
+	if games[0].Opponent != "" {
+		t.Errorf("Expected no opponent, got '%s'", games[0].Opponent)
+	}
+}
+
+func TestCSVParser_ParseScheduleMatching(t *testing.T) {
+	csvData := `Team Captain ,Team #,Win %,Division ,Wins,Loss,time,8/21/2025
+Alex Lugo #1,1,66.67%,Comp Div 1 AG,4,2,7:00 PM,ct 7
+Alex Lugo # 2,2,50.00%,Comp Div 1 AG,3,3,8:00 PM,ct 8
+Ann,2,50.00%,Rec Div 2,3,3,7:00 PM,ct 9`
+
+	// The default substring match picks up both of Alex's teams.
+	parser := NewCSVParser("Alex Lugo")
+	games, err := parser.ParseSchedule(csvData)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+	if len(games) != 2 || len(parser.MatchedTeams()) != 2 {
+		t.Fatalf("Expected 2 games from 2 rows, got %d games from %v", len(games), parser.MatchedTeams())
+	}
+
+	parser = NewCSVParserMatching(func(captain string, teamNum int, division string) bool {
+		return teamNum == 2 && division == "Comp Div 1 AG"
+	}, time.Local)
+	games, err = parser.ParseSchedule(csvData)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+	if len(games) != 1 {
+		t.Fatalf("Expected 1 game, got %d", len(games))
+	}
+	if games[0].TeamCaptain != "Alex Lugo # 2" || games[0].Court != "8" {
+		t.Errorf("Expected Alex Lugo # 2 on court 8, got %s on court %s", games[0].TeamCaptain, games[0].Court)
+	}
+	want := []Team{{Captain: "Alex Lugo # 2", Number: 2, Division: "Comp Div 1 AG"}}
+	if got := parser.MatchedTeams(); len(got) != 1 || got[0] != want[0] {
+		t.Errorf("MatchedTeams() = %v, want %v", got, want)
+	}
+}
 
+func TestParseDateHeader(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected time.Time
+		expected dateHeader
+		ok       bool
+	}{
+		{input: "8/21/2025", expected: dateHeader{month: 8, day: 21, year: 2025}, ok: true},
+		{input: "12/31/24", expected: dateHeader{month: 12, day: 31, year: 2024}, ok: true},
+		{input: "1/15", expected: dateHeader{month: 1, day: 15}, ok: true},
+		{input: "time,8/21/2025", expected: dateHeader{month: 8, day: 21, year: 2025}, ok: true}, // Should handle prefix
+		{input: "Time,08/28", expected: dateHeader{month: 8, day: 28}, ok: true},
+		{input: "2/29", expected: dateHeader{month: 2, day: 29}, ok: true}, // checked against the year later
+		{input: "invalid", ok: false},
+		{input: "13/01", ok: false},
+		{input: "4/31", ok: false},
+		{input: "TBD/TBA", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, ok := parseDateHeader(tt.input)
+			if ok != tt.ok {
+				t.Fatalf("parseDateHeader(%s) ok = %v, want %v", tt.input, ok, tt.ok)
+			}
+			if ok && result != tt.expected {
+				t.Errorf("parseDateHeader(%s) = %+v, want %+v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCSVParser_ResolveDates(t *testing.T) {
+	day := func(year, month, d int) time.Time {
+		return time.Date(year, time.Month(month), d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		headers  []string
+		expected []time.Time // per date column; zero if it should be skipped
+		problems int
 	}{
 		{
-			input:    "8/21/2025",
-			expected: time.Date(2025, 8, 21, 0, 0, 0, 0, time.Local),
+			name:     "anchored spring season",
+			now:      day(2025, 10, 1),
+			headers:  []string{"4/2/2026", "04/09", "04/16"},
+			expected: []time.Time{day(2026, 4, 2), day(2026, 4, 9), day(2026, 4, 16)},
 		},
 		{
-			input:    "12/31/24",
-			expected: time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local),
+			name:     "fall season into January",
+			now:      day(2025, 10, 1),
+			headers:  []string{"10/30/2025", "11/06", "12/18", "01/08", "01/15"},
+			expected: []time.Time{day(2025, 10, 30), day(2025, 11, 6), day(2025, 12, 18), day(2026, 1, 8), day(2026, 1, 15)},
 		},
 		{
-			input:    "1/15", // Should use current year
-			expected: time.Date(currentYear, 1, 15, 0, 0, 0, 0, time.Local),
+			name:     "anchor after the wrap",
+			now:      day(2025, 10, 1),
+			headers:  []string{"11/20", "12/18", "1/8/2026", "1/15"},
+			expected: []time.Time{day(2025, 11, 20), day(2025, 12, 18), day(2026, 1, 8), day(2026, 1, 15)},
 		},
 		{
-			input:    "time,8/21/2025", // Should handle prefix
-			expected: time.Date(2025, 8, 21, 0, 0, 0, 0, time.Local),
+			name:     "no anchor, December headers seen in January",
+			now:      day(2026, 1, 3),
+			headers:  []string{"12/04", "12/11", "1/08"},
+			expected: []time.Time{day(2025, 12, 4), day(2025, 12, 11), day(2026, 1, 8)},
 		},
 		{
-			input:    "Time,08/28", // Should handle prefix and use current year
-			expected: time.Date(currentYear, 8, 28, 0, 0, 0, 0, time.Local),
+			name:     "no anchor, next season posted early",
+			now:      day(2025, 12, 20),
+			headers:  []string{"1/08", "1/15"},
+			expected: []time.Time{day(2026, 1, 8), day(2026, 1, 15)},
 		},
 		{
-			input:    "invalid",
-			expected: time.Time{}, // Zero time for invalid input
+			name:     "unparseable header is flagged",
+			now:      day(2025, 10, 1),
+			headers:  []string{"4/2/2026", "TBD/TBA", "04/16"},
+			expected: []time.Time{day(2026, 4, 2), {}, day(2026, 4, 16)},
+			problems: 1,
+		},
+		{
+			name:     "leap day outside a leap year",
+			now:      day(2026, 10, 1),
+			headers:  []string{"2/22/2027", "2/29"},
+			expected: []time.Time{day(2027, 2, 22), {}},
+			problems: 1,
 		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			result := parser.parseDate(tt.input)
-			if !result.Equal(tt.expected) {
-				t.Errorf("parseDate(%s) = %v, want %v", tt.input, result, tt.expected)
+		t.Run(tt.name, func(t *testing.T) {
+			parser := &CSVParser{loc: time.Local, now: tt.now}
+			dateColumns := parser.findDateColumns(tt.headers)
+			dates, problems := parser.resolveDates(tt.headers, dateColumns)
+
+			if len(problems) != tt.problems {
+				t.Errorf("got %d problems %v, want %d", len(problems), problems, tt.problems)
+			}
+			for i, want := range tt.expected {
+				got, ok := dates[i]
+				if want.IsZero() {
+					if ok {
+						t.Errorf("column %d (%s) = %v, want it skipped", i, tt.headers[i], got)
+					}
+					continue
+				}
+				if !got.Equal(want) {
+					t.Errorf("column %d (%s) = %v, want %v", i, tt.headers[i], got, want)
+				}
 			}
 		})
 	}
 }
 
+func TestCSVParser_ParseScheduleLocation(t *testing.T) {
+	csvData := `Team Captain ,Team #,Win %,Division ,Wins,Loss,time,3/5/2026,time,3/12/2026
+Jeff,1,66.67%,Comp Div 1 AG,4,2,7:00 PM,ct 7,7:00 PM,ct 7`
+
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	parser := NewCSVParserMatching(func(captain string, _ int, _ string) bool { return captain == "Jeff" }, denver)
+	games, err := parser.ParseSchedule(csvData)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	// The second game is after the switch to daylight saving time.
+	want := []time.Time{
+		time.Date(2026, 3, 5, 0, 0, 0, 0, denver),
+		time.Date(2026, 3, 12, 0, 0, 0, 0, denver),
+	}
+	if len(games) != len(want) {
+		t.Fatalf("Expected %d games, got %d", len(want), len(games))
+	}
+	for i, game := range games {
+		if !game.Date.Equal(want[i]) || game.Date.Location() != denver {
+			t.Errorf("Game %d date = %v, want %v", i, game.Date, want[i])
+		}
+		wantStart := want[i].Add(19 * time.Hour)
+		if !game.StartsAt.Equal(wantStart) {
+			t.Errorf("Game %d starts at %v, want %v", i, game.StartsAt, wantStart)
+		}
+	}
+	// 7 pm is 02:00 UTC in winter and 01:00 UTC in summer time.
+	if got := games[0].StartsAt.UTC().Hour(); got != 2 {
+		t.Errorf("Game 0 starts at %d:00 UTC, want 2:00", got)
+	}
+	if got := games[1].StartsAt.UTC().Hour(); got != 1 {
+		t.Errorf("Game 1 starts at %d:00 UTC, want 1:00", got)
+	}
+}
+
+func TestCSVParser_ParseScheduleSkipsBadDates(t *testing.T) {
+	csvData := `Team Captain ,Team #,Win %,Division ,Wins,Loss,time,4/2/2026,time,TBD/TBA,time,04/16
+Jeff,1,66.67%,Comp Div 1 AG,4,2,7:00 PM,ct 7,8:00 PM,ct 8,7:00 PM,ct 7`
+
+	parser := NewCSVParser("Jeff")
+	games, err := parser.ParseSchedule(csvData)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	if len(games) != 2 {
+		t.Fatalf("Expected 2 games, got %d", len(games))
+	}
+	for _, game := range games {
+		if game.Date.IsZero() {
+			t.Errorf("Game %s has a zero date", game.Raw)
+		}
+	}
+	if len(parser.Warnings()) != 1 {
+		t.Errorf("Expected 1 warning, got %v", parser.Warnings())
+	}
+}
+
 func TestCSVParser_GenerateGameID(t *testing.T) {
 	parser := NewCSVParser("test")
 	date := time.Date(2025, 8, 21, 0, 0, 0, 0, time.Local)
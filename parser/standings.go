@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aweist/schedule-watcher/models"
+)
+
+// ParseStandings reads the Win %, Wins and Loss columns of an IVP schedule
+// CSV into one standing per team row, ranked within each division. Teams
+// that haven't played yet (Win % of "#DIV/0!") are listed with no record and
+// ranked last.
+func ParseStandings(csvData string) ([]models.Standing, error) {
+	reader := csv.NewReader(strings.NewReader(csvData))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV: %w", err)
+	}
+
+	if len(records) < 2 {
+		return nil, fmt.Errorf("insufficient data in CSV")
+	}
+
+	colMap := buildColumnMap(records[0])
+	if colMap.wins == -1 || colMap.losses == -1 {
+		return nil, fmt.Errorf("no Wins/Loss columns in CSV headers")
+	}
+
+	var standings []models.Standing
+	for _, row := range records[1:] {
+		teamName := cell(row, colMap.captain)
+		if teamName == "" || strings.Contains(teamName, "Fall Schedule") {
+			continue
+		}
+
+		teamNum, _ := strconv.Atoi(cell(row, colMap.teamNum))
+		wins, _ := strconv.Atoi(cell(row, colMap.wins))
+		losses, _ := strconv.Atoi(cell(row, colMap.losses))
+
+		winPct := 0.0
+		if played := wins + losses; played > 0 {
+			winPct = float64(wins) / float64(played)
+		}
+		if pct, ok := parsePercent(cell(row, colMap.winPct)); ok {
+			winPct = pct
+		}
+
+		standings = append(standings, models.Standing{
+			TeamName:   teamName,
+			TeamNumber: teamNum,
+			Division:   cell(row, colMap.division),
+			Wins:       wins,
+			Losses:     losses,
+			WinPct:     winPct,
+		})
+	}
+
+	rankStandings(standings)
+	return standings, nil
+}
+
+// Team is one team row of an IVP schedule CSV.
+type Team struct {
+	Captain  string
+	Number   int
+	Division string
+}
+
+// ParseTeams lists the teams in an IVP schedule CSV, in row order.
+func ParseTeams(csvData string) ([]Team, error) {
+	reader := csv.NewReader(strings.NewReader(csvData))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV: %w", err)
+	}
+
+	if len(records) < 2 {
+		return nil, fmt.Errorf("insufficient data in CSV")
+	}
+
+	colMap := buildColumnMap(records[0])
+
+	var teams []Team
+	for _, row := range records[1:] {
+		captain := cell(row, colMap.captain)
+		if captain == "" || strings.Contains(captain, "Fall Schedule") {
+			continue
+		}
+		number, _ := strconv.Atoi(cell(row, colMap.teamNum))
+		teams = append(teams, Team{
+			Captain:  captain,
+			Number:   number,
+			Division: cell(row, colMap.division),
+		})
+	}
+	return teams, nil
+}
+
+// cell returns the trimmed value of column i, or "" if the row is short.
+func cell(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// parsePercent parses "66.67%" as 0.6667.
+func parsePercent(s string) (float64, bool) {
+	s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v / 100, true
+}
+
+// rankStandings orders standings by division, then win percentage, then
+// wins, and numbers each team's position within its division. Teams with no
+// games played sort after everyone else. Ties share a rank.
+func rankStandings(standings []models.Standing) {
+	played := func(s models.Standing) bool { return s.Wins+s.Losses > 0 }
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Division != b.Division {
+			return a.Division < b.Division
+		}
+		if played(a) != played(b) {
+			return played(a)
+		}
+		if a.WinPct != b.WinPct {
+			return a.WinPct > b.WinPct
+		}
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		return a.TeamNumber < b.TeamNumber
+	})
+
+	for i := range standings {
+		s := &standings[i]
+		switch {
+		case i == 0 || standings[i-1].Division != s.Division:
+			s.Rank = 1
+		case standings[i-1].WinPct == s.WinPct && standings[i-1].Wins == s.Wins && played(standings[i-1]) == played(*s):
+			s.Rank = standings[i-1].Rank
+		default:
+			// Count positions, not distinct records: 1, 1, 3.
+			pos := 1
+			for j := i - 1; j >= 0 && standings[j].Division == s.Division; j-- {
+				pos++
+			}
+			s.Rank = pos
+		}
+	}
+}
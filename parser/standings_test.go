@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/aweist/schedule-watcher/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadExample2026(t *testing.T) string {
+	data, err := os.ReadFile("../docs/csv/example_2026/example_2026.json")
+	require.NoError(t, err)
+
+	var schedule models.Schedule
+	require.NoError(t, json.Unmarshal(data, &schedule))
+	return schedule.CSVData
+}
+
+func findStanding(standings []models.Standing, division, team string) *models.Standing {
+	for i := range standings {
+		if standings[i].Division == division && standings[i].TeamName == team {
+			return &standings[i]
+		}
+	}
+	return nil
+}
+
+func TestParseStandings_Example2026(t *testing.T) {
+	standings, err := ParseStandings(loadExample2026(t))
+	require.NoError(t, err)
+	assert.Len(t, standings, 17+27+6)
+
+	taylor := findStanding(standings, "Comp 4s AG", "Taylor Sisneros")
+	require.NotNil(t, taylor)
+	assert.Equal(t, 3, taylor.TeamNumber)
+	assert.Equal(t, 2, taylor.Wins)
+	assert.Equal(t, 1, taylor.Losses)
+	assert.InDelta(t, 0.6667, taylor.WinPct, 0.0001)
+	// Four teams are 3-0 in the division; the 2-1 teams share 5th.
+	assert.Equal(t, 5, taylor.Rank)
+
+	leader := findStanding(standings, "Comp 4s AG", "Cory G")
+	require.NotNil(t, leader)
+	assert.Equal(t, 1, leader.Rank)
+
+	// A team that hasn't played has no record and ranks last.
+	trent := findStanding(standings, "Comp 4s AG", "Trent Thompson")
+	require.NotNil(t, trent)
+	assert.Equal(t, 0, trent.Wins+trent.Losses)
+	assert.Equal(t, 17, trent.Rank)
+
+	// The same captain in two divisions gets a standing in each.
+	assert.NotNil(t, findStanding(standings, "Comp 4s AG", "Jeff Hoover"))
+	assert.NotNil(t, findStanding(standings, "Fun 4s  AG", "Jeff Hoover"))
+}
+
+func TestParseStandings_RanksWithinDivision(t *testing.T) {
+	csvData := `Team Captain,Team #,Win %,Division,Wins,Loss
+Ann,1,50.00%,A,1,1
+Bob,2,100.00%,A,2,0
+Cat,3,50.00%,A,1,1
+Dan,1,0.00%,B,0,2
+Fall Schedule,,#DIV/0!,,,`
+
+	standings, err := ParseStandings(csvData)
+	require.NoError(t, err)
+	require.Len(t, standings, 4)
+
+	var got []string
+	var ranks []int
+	for _, s := range standings {
+		got = append(got, s.TeamName)
+		ranks = append(ranks, s.Rank)
+	}
+	assert.Equal(t, []string{"Bob", "Ann", "Cat", "Dan"}, got)
+	assert.Equal(t, []int{1, 2, 2, 1}, ranks)
+}
+
+func TestParseStandings_NoRecordColumns(t *testing.T) {
+	_, err := ParseStandings("Team Captain,Team #,Division\nAnn,1,A")
+	assert.Error(t, err)
+}
+
+func TestParseTeams(t *testing.T) {
+	csvData := `Team Captain,Team #,Win %,Division,Wins,Loss,time,8/21/2025
+Ann,1,50.00%,A,1,1,7:00 PM,ct 7
+Fall Schedule,,,,,,,
+Bob,2,100.00%,B,2,0,8:00 PM,ct 8`
+
+	teams, err := ParseTeams(csvData)
+	require.NoError(t, err)
+	assert.Equal(t, []Team{
+		{Captain: "Ann", Number: 1, Division: "A"},
+		{Captain: "Bob", Number: 2, Division: "B"},
+	}, teams)
+}
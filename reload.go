@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/aweist/schedule-watcher/config"
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/scheduler"
+	"github.com/aweist/schedule-watcher/storage"
+	"github.com/aweist/schedule-watcher/web"
+)
+
+// reloader re-reads the config file and swaps freshly built leagues into the
+// running components, migrating and cleaning up stored data as at startup.
+// Settings outside of leagues (poll interval, web port, storage, SMTP) are
+// reported but only take effect on restart.
+type reloader struct {
+	mu       sync.Mutex
+	path     string
+	db       *storage.BoltStorage
+	cfg      *config.Config
+	poller   *scheduler.Poller
+	reminder *scheduler.DailyReminder
+	web      *web.Server
+}
+
+// Reload applies the config file's current contents and returns the changes
+// it found. On any error the running configuration is left untouched.
+func (r *reloader) Reload() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := config.Load(r.path)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(league.ValidateConfig); err != nil {
+		return nil, err
+	}
+
+	changes := config.Diff(r.cfg, cfg)
+	if len(changes) == 0 {
+		log.Println("Config reloaded, no changes")
+		return nil, nil
+	}
+
+	leagues, err := buildLeagues(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("rebuilding leagues: %w", err)
+	}
+	migrateStorage(r.db, cfg)
+	migrateGameTimes(r.db, leagues)
+
+	r.poller.SetLeagues(leagues)
+	r.reminder.SetLeagues(leagues)
+	if r.web != nil {
+		r.web.SetLeagues(leagues)
+	}
+	r.cfg = cfg
+
+	log.Printf("Config reloaded with %d change(s):", len(changes))
+	for _, c := range changes {
+		log.Printf("  %s", c)
+	}
+	return changes, nil
+}
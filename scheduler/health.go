@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/models"
+)
+
+// recordHealth updates the league's health record with the outcome of this
+// poll's fetch (fetchErr is nil on success). Once a league has failed
+// alertAfter polls in a row the admin is emailed, once per outage, and again
+// when it recovers. An outage alert that can't be sent is retried on the next
+// failed poll.
+func (p *Poller) recordHealth(lg league.League, fetchErr error) {
+	health, err := p.storage.GetHealth(lg.Name())
+	if err != nil {
+		log.Printf("Error reading health for %s: %v", lg.DisplayName(), err)
+		return
+	}
+	if health == nil {
+		health = &models.LeagueHealth{League: lg.Name()}
+	}
+	health.DisplayName = lg.DisplayName()
+	now := time.Now()
+
+	if fetchErr == nil {
+		health.LastSuccess = now
+		if !health.Healthy() {
+			log.Printf("%s: fetching again after %d failed poll(s)", lg.DisplayName(), health.ConsecutiveFailures)
+			if health.AlertSent {
+				p.sendRecoveryAlert(*health)
+			}
+		}
+		health.ConsecutiveFailures = 0
+		health.FailingSince = time.Time{}
+		health.AlertSent = false
+	} else {
+		if health.Healthy() {
+			health.FailingSince = now
+		}
+		health.ConsecutiveFailures++
+		health.LastFailure = now
+		health.LastError = fetchErr.Error()
+		if health.ConsecutiveFailures >= p.alertAfter && !health.AlertSent {
+			health.AlertSent = p.sendOutageAlert(*health)
+		}
+	}
+
+	if err := p.storage.SaveHealth(*health); err != nil {
+		log.Printf("Error saving health for %s: %v", lg.DisplayName(), err)
+	}
+}
+
+// sendOutageAlert emails the admin that a league keeps failing. It reports
+// whether the alert went out; with no admin address or notifier there is
+// no one to tell, and the outage is only logged.
+func (p *Poller) sendOutageAlert(health models.LeagueHealth) bool {
+	log.Printf("%s: %d failed polls in a row since %s", health.DisplayName, health.ConsecutiveFailures, health.FailingSince.Format(time.RFC3339))
+	if p.notifier == nil || p.alertEmail == "" {
+		return false
+	}
+
+	if err := p.notifier.SendOutageAlert(health, []string{p.alertEmail}); err != nil {
+		log.Printf("Error sending %s outage alert for %s: %v", p.notifier.GetType(), health.DisplayName, err)
+		return false
+	}
+	log.Printf("Sent %s outage alert for %s to %s", p.notifier.GetType(), health.DisplayName, p.alertEmail)
+	return true
+}
+
+// sendRecoveryAlert emails the admin that a league they were alerted about is
+// fetching again. Failures are logged and not retried.
+func (p *Poller) sendRecoveryAlert(health models.LeagueHealth) {
+	if p.notifier == nil || p.alertEmail == "" {
+		return
+	}
+
+	if err := p.notifier.SendRecoveryAlert(health, []string{p.alertEmail}); err != nil {
+		log.Printf("Error sending %s recovery alert for %s: %v", p.notifier.GetType(), health.DisplayName, err)
+		return
+	}
+	log.Printf("Sent %s recovery alert for %s to %s", p.notifier.GetType(), health.DisplayName, p.alertEmail)
+}
@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/aweist/schedule-watcher/models"
+	"github.com/aweist/schedule-watcher/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// alertRecorder is a notifier that records the health alerts sent.
+type alertRecorder struct {
+	outages    []models.LeagueHealth
+	recoveries []models.LeagueHealth
+	fail       bool
+}
+
+func (n *alertRecorder) SendNotification(models.Game, []string) error       { return nil }
+func (n *alertRecorder) SendResultNotification(models.Game, []string) error { return nil }
+func (n *alertRecorder) SendSeasonNotification(models.Season, []string) error {
+	return nil
+}
+func (n *alertRecorder) GetType() string { return "test" }
+
+func (n *alertRecorder) SendOutageAlert(health models.LeagueHealth, recipients []string) error {
+	if n.fail {
+		return errors.New("smtp down")
+	}
+	n.outages = append(n.outages, health)
+	return nil
+}
+
+func (n *alertRecorder) SendRecoveryAlert(health models.LeagueHealth, recipients []string) error {
+	n.recoveries = append(n.recoveries, health)
+	return nil
+}
+
+func TestRecordHealth(t *testing.T) {
+	db, err := storage.NewBoltStorage(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	alerts := &alertRecorder{}
+	p := NewPoller(PollerConfig{Storage: db, Notifier: alerts, AlertEmail: "admin@example.com", AlertAfter: 3})
+	lg := &slowLeague{name: "pins", inFlight: &gauge{}}
+	fetchErr := errors.New("unexpected status code: 502")
+
+	p.recordHealth(lg, nil)
+	health, err := db.GetHealth("pins")
+	require.NoError(t, err)
+	require.NotNil(t, health)
+	assert.True(t, health.Healthy())
+	assert.False(t, health.LastSuccess.IsZero())
+
+	// The alert waits for the third failure in a row, and a failed send is
+	// retried on the next failure.
+	alerts.fail = true
+	for i := 0; i < 3; i++ {
+		p.recordHealth(lg, fetchErr)
+	}
+	assert.Empty(t, alerts.outages)
+	alerts.fail = false
+	p.recordHealth(lg, fetchErr)
+	p.recordHealth(lg, fetchErr)
+	require.Len(t, alerts.outages, 1, "one alert per outage")
+	assert.Equal(t, 4, alerts.outages[0].ConsecutiveFailures)
+
+	health, err = db.GetHealth("pins")
+	require.NoError(t, err)
+	assert.Equal(t, 5, health.ConsecutiveFailures)
+	assert.Equal(t, fetchErr.Error(), health.LastError)
+	assert.True(t, health.AlertSent)
+	assert.False(t, health.FailingSince.IsZero())
+
+	p.recordHealth(lg, nil)
+	require.Len(t, alerts.recoveries, 1)
+	assert.Equal(t, 5, alerts.recoveries[0].ConsecutiveFailures, "the recovery alert says how long it was down")
+
+	health, err = db.GetHealth("pins")
+	require.NoError(t, err)
+	assert.True(t, health.Healthy())
+	assert.False(t, health.AlertSent)
+	assert.True(t, health.FailingSince.IsZero())
+	assert.Equal(t, fetchErr.Error(), health.LastError, "the last error is kept")
+
+	// A short outage recovers without any alerts.
+	p.recordHealth(lg, fetchErr)
+	p.recordHealth(lg, nil)
+	assert.Len(t, alerts.outages, 1)
+	assert.Len(t, alerts.recoveries, 1)
+}
@@ -1,9 +1,13 @@
 package scheduler
 
 import (
+	"context"
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/aweist/schedule-watcher/league"
@@ -12,122 +16,266 @@ import (
 	"github.com/aweist/schedule-watcher/storage"
 )
 
+// Defaults for the PollerConfig fields left zero.
+const (
+	DefaultLeagueTimeout = 2 * time.Minute
+	DefaultMaxConcurrent = 4
+	DefaultAlertAfter    = 3
+)
+
 type Poller struct {
-	leagues  []league.League
-	storage  *storage.BoltStorage
-	notifier notifier.Notifier
-	interval time.Duration
+	mu            sync.RWMutex
+	leagues       []league.League
+	storage       *storage.BoltStorage
+	notifier      notifier.Notifier
+	interval      time.Duration
+	leagueTimeout time.Duration
+	maxConcurrent int
+	alertEmail    string
+	alertAfter    int
 }
 
 type PollerConfig struct {
-	Leagues  []league.League
-	Storage  *storage.BoltStorage
-	Notifier notifier.Notifier
-	Interval time.Duration
+	Leagues       []league.League
+	Storage       *storage.BoltStorage
+	Notifier      notifier.Notifier
+	Interval      time.Duration
+	LeagueTimeout time.Duration // longest one league's fetch may take
+	MaxConcurrent int           // leagues fetched at once
+	AlertEmail    string        // admin told about failing leagues; none if empty
+	AlertAfter    int           // failed polls in a row before alerting
 }
 
 func NewPoller(config PollerConfig) *Poller {
+	if config.LeagueTimeout <= 0 {
+		config.LeagueTimeout = DefaultLeagueTimeout
+	}
+	if config.MaxConcurrent < 1 {
+		config.MaxConcurrent = DefaultMaxConcurrent
+	}
+	if config.AlertAfter < 1 {
+		config.AlertAfter = DefaultAlertAfter
+	}
 	return &Poller{
-		leagues:  config.Leagues,
-		storage:  config.Storage,
-		notifier: config.Notifier,
-		interval: config.Interval,
+		leagues:       config.Leagues,
+		storage:       config.Storage,
+		notifier:      config.Notifier,
+		interval:      config.Interval,
+		leagueTimeout: config.LeagueTimeout,
+		maxConcurrent: config.MaxConcurrent,
+		alertEmail:    config.AlertEmail,
+		alertAfter:    config.AlertAfter,
 	}
 }
 
-func (p *Poller) Start() {
+// SetLeagues swaps the leagues polled from the next poll onwards. A poll
+// already in progress finishes with the leagues it started with, so games it
+// has fetched are still saved and notified.
+func (p *Poller) SetLeagues(leagues []league.League) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.leagues = leagues
+}
+
+func (p *Poller) currentLeagues() []league.League {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.leagues
+}
+
+// Start polls every interval until ctx is cancelled. A poll in progress then
+// abandons its fetches and stops before the next game, so Start returns
+// without cutting off a send or a database write; games it didn't get to are
+// picked up by the next run.
+func (p *Poller) Start(ctx context.Context) {
 	log.Println("Starting schedule poller...")
 
-	p.poll()
+	p.poll(ctx)
 
 	ticker := time.NewTicker(p.interval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		p.poll()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Schedule poller stopped")
+			return
+		case <-ticker.C:
+			p.poll(ctx)
+		}
 	}
 }
 
-func (p *Poller) poll() {
-	for _, lg := range p.leagues {
-		log.Printf("Polling league: %s", lg.DisplayName())
+// poll fetches the leagues in parallel and processes their results one league
+// at a time, in order. Only fetching is concurrent: saving, snapshots and
+// emails all happen on this goroutine, so Bolt writes and notifications come
+// in the same order every poll whichever fetch finishes first. Each league's
+// outcome is recorded in its health record (recordHealth).
+func (p *Poller) poll(ctx context.Context) {
+	leagues := p.currentLeagues()
+	results := p.fetchAll(ctx, leagues)
+
+	for i, lg := range leagues {
+		res := <-results[i]
+		if ctx.Err() != nil {
+			return
+		}
+		p.recordHealth(lg, res.err)
+		if res.err != nil {
+			log.Printf("Error fetching %s: %v", lg.DisplayName(), res.err)
+			// The teams that were fetched are still saved.
+			var partial *league.PartialError
+			if !errors.As(res.err, &partial) {
+				continue
+			}
+		}
+		p.processLeague(ctx, lg, res.teamGames)
+	}
+	if ctx.Err() != nil {
+		return
+	}
 
-		teamGames, err := lg.FetchAndParse()
-		if err != nil {
-			log.Printf("Error fetching %s: %v", lg.DisplayName(), err)
+	oneMonthAgo := time.Now().AddDate(0, -1, 0)
+	if err := p.storage.CleanupOldNotifications(oneMonthAgo); err != nil {
+		log.Printf("Error cleaning up old notifications: %v", err)
+	}
+}
+
+// fetchResult is what one league's FetchAndParse returned.
+type fetchResult struct {
+	teamGames map[string][]models.Game
+	err       error
+}
+
+// fetchAll starts fetching leagues on up to maxConcurrent goroutines and
+// returns a channel per league, in the same order, that receives its result.
+// Every channel gets a result, an error once ctx is cancelled, so callers can
+// wait on them in turn without leaking workers.
+func (p *Poller) fetchAll(ctx context.Context, leagues []league.League) []chan fetchResult {
+	results := make([]chan fetchResult, len(leagues))
+	for i := range results {
+		results[i] = make(chan fetchResult, 1)
+	}
+
+	jobs := make(chan int, len(leagues))
+	for i := range leagues {
+		jobs <- i
+	}
+	close(jobs)
+
+	workers := p.maxConcurrent
+	if workers > len(leagues) {
+		workers = len(leagues)
+	}
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range jobs {
+				results[i] <- p.fetch(ctx, leagues[i])
+			}
+		}()
+	}
+	return results
+}
+
+// fetch runs one league's FetchAndParse under the per-league timeout. A
+// league that runs out of time is reported like any other failed fetch, and
+// the others' results are processed as usual.
+func (p *Poller) fetch(ctx context.Context, lg league.League) fetchResult {
+	if err := ctx.Err(); err != nil {
+		return fetchResult{err: err}
+	}
+	log.Printf("Polling league: %s", lg.DisplayName())
+
+	leagueCtx, cancel := context.WithTimeout(ctx, p.leagueTimeout)
+	defer cancel()
+	teamGames, err := lg.FetchAndParse(leagueCtx)
+	if err != nil && ctx.Err() == nil && leagueCtx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %s: %w", p.leagueTimeout, err)
+	}
+	return fetchResult{teamGames: teamGames, err: err}
+}
+
+// processLeague saves a league's freshly fetched games and sends what they
+// call for. It stops before the next game once ctx is cancelled.
+func (p *Poller) processLeague(ctx context.Context, lg league.League, teamGames map[string][]models.Game) {
+	p.maybeSaveSnapshot(lg, teamGames)
+	p.maybeSaveStandings(lg)
+	held := p.announceSeasons(lg)
+
+	for _, team := range lg.Teams() {
+		games := teamGames[team.Key]
+		if len(games) == 0 {
 			continue
 		}
 
-		p.maybeSaveSnapshot(lg, teamGames)
+		newGamesFound := 0
+		for _, game := range games {
+			if ctx.Err() != nil {
+				return
+			}
+			if held[scopedGameKey(game)] {
+				continue
+			}
+			if game.Date.Before(time.Now().AddDate(0, 0, -1)) {
+				// Past games are never new, but their results may
+				// have just been posted.
+				if err := p.updateStoredGame(lg, game); err != nil {
+					log.Printf("Error updating game %s: %v", game.ID, err)
+				}
+				continue
+			}
 
-		for _, team := range lg.Teams() {
-			games := teamGames[team.Key]
-			if len(games) == 0 {
+			isNew, err := p.saveNewGame(lg, game)
+			if err != nil {
+				log.Printf("Error saving game %s: %v", game.ID, err)
 				continue
 			}
 
-			newGamesFound := 0
-			for _, game := range games {
-				if game.Date.Before(time.Now().AddDate(0, 0, -1)) {
-					continue
-				}
+			if isNew {
+				newGamesFound++
+			}
 
-				isNew, err := p.saveNewGame(game)
+			// Immediate mode: notify any game not yet marked notified.
+			// Gating on notification state (rather than isNew) lets transient
+			// SMTP failures retry on subsequent polls.
+			if lg.NotifyMode() == league.NotifyImmediate {
+				notified, err := p.storage.IsGameNotified(game.League, game.TeamKey, game.ID)
 				if err != nil {
-					log.Printf("Error saving game %s: %v", game.ID, err)
+					log.Printf("Error checking notification status for %s: %v", game.ID, err)
 					continue
 				}
-
-				if isNew {
-					newGamesFound++
+				if notified {
+					continue
 				}
-
-				// Immediate mode: notify any game not yet marked notified.
-				// Gating on notification state (rather than isNew) lets transient
-				// SMTP failures retry on subsequent polls.
-				if lg.NotifyMode() == league.NotifyImmediate {
-					notified, err := p.storage.IsGameNotified(game.League, game.TeamKey, game.ID)
-					if err != nil {
-						log.Printf("Error checking notification status for %s: %v", game.ID, err)
-						continue
-					}
-					if notified {
-						continue
-					}
-					if err := p.sendNotification(game); err != nil {
-						continue
-					}
-					if err := p.storage.MarkGameNotified(game); err != nil {
-						log.Printf("Error marking game as notified: %v", err)
-					}
+				if err := p.sendNotification(game); err != nil {
+					continue
 				}
-			}
-
-			if newGamesFound > 0 {
-				if lg.NotifyMode() == league.NotifyImmediate {
-					log.Printf("%s/%s: found %d new games and sent notifications", lg.DisplayName(), team.Key, newGamesFound)
-				} else {
-					log.Printf("%s/%s: saved %d new games (reminders will be sent on game day)", lg.DisplayName(), team.Key, newGamesFound)
+				if err := p.storage.MarkGameNotified(game); err != nil {
+					log.Printf("Error marking game as notified: %v", err)
 				}
 			}
 		}
-	}
 
-	oneMonthAgo := time.Now().AddDate(0, -1, 0)
-	if err := p.storage.CleanupOldNotifications(oneMonthAgo); err != nil {
-		log.Printf("Error cleaning up old notifications: %v", err)
+		if newGamesFound > 0 {
+			if lg.NotifyMode() == league.NotifyImmediate {
+				log.Printf("%s/%s: found %d new games and sent notifications", lg.DisplayName(), team.Key, newGamesFound)
+			} else {
+				log.Printf("%s/%s: saved %d new games (reminders will be sent on game day)", lg.DisplayName(), team.Key, newGamesFound)
+			}
+		}
 	}
 }
 
 // saveNewGame saves a game if it doesn't already exist. Returns true if the game is new.
-func (p *Poller) saveNewGame(game models.Game) (bool, error) {
+// Games that already exist are refreshed by updateGame instead.
+func (p *Poller) saveNewGame(lg league.League, game models.Game) (bool, error) {
 	existingGame, err := p.storage.GetGame(game.League, game.TeamKey, game.ID)
 	if err != nil {
 		return false, fmt.Errorf("getting existing game: %w", err)
 	}
 
 	if existingGame != nil {
-		return false, nil
+		return false, p.updateGame(lg, *existingGame, game)
 	}
 
 	if err := p.storage.SaveGame(game); err != nil {
@@ -137,6 +285,72 @@ func (p *Poller) saveNewGame(game models.Game) (bool, error) {
 	return true, nil
 }
 
+// updateStoredGame refreshes a game we already have stored. Games we never
+// stored are ignored rather than saved, so past games aren't announced.
+func (p *Poller) updateStoredGame(lg league.League, game models.Game) error {
+	existingGame, err := p.storage.GetGame(game.League, game.TeamKey, game.ID)
+	if err != nil {
+		return fmt.Errorf("getting existing game: %w", err)
+	}
+	if existingGame == nil {
+		return nil
+	}
+	return p.updateGame(lg, *existingGame, game)
+}
+
+// updateGame re-saves a stored game when details that are filled in after it
+// first appears have changed: its opponent, its result once scores are
+// posted, its start and end (a changed game_duration or timezone), or the
+// league's name and type shown in emails (games stored before they were
+// recorded, or a renamed league). A newly posted result is emailed if the
+// league asks for it; other updates never re-notify.
+func (p *Poller) updateGame(lg league.League, existing, game models.Game) error {
+	opponentChanged := existing.Opponent != game.Opponent
+	resultPosted := game.Result != nil && !sameResult(existing.Result, game.Result)
+	timesChanged := !existing.StartsAt.Equal(game.StartsAt) || !existing.EndsAt.Equal(game.EndsAt)
+	labelsChanged := existing.LeagueName != game.LeagueName || existing.LeagueType != game.LeagueType
+	if !opponentChanged && !resultPosted && !timesChanged && !labelsChanged {
+		return nil
+	}
+
+	existing.LeagueName, existing.LeagueType = game.LeagueName, game.LeagueType
+
+	if timesChanged {
+		existing.Date, existing.Timezone = game.Date, game.Timezone
+		existing.StartsAt, existing.EndsAt = game.StartsAt, game.EndsAt
+	}
+
+	if opponentChanged {
+		existing.Opponent = game.Opponent
+		log.Printf("%s/%s: game %s opponent is now %q", game.League, game.TeamKey, game.ID, game.Opponent)
+	}
+	if resultPosted {
+		result := *game.Result
+		result.PostedAt = time.Now()
+		existing.Result = &result
+		log.Printf("%s/%s: game %s result posted: %d-%d", game.League, game.TeamKey, game.ID, result.GamesWon, result.GamesLost)
+	}
+
+	if err := p.storage.SaveGame(existing); err != nil {
+		return fmt.Errorf("saving game: %w", err)
+	}
+
+	if resultPosted {
+		if reporter, ok := lg.(league.ResultReporter); ok && reporter.NotifyResults() {
+			p.sendResultNotification(existing)
+		}
+	}
+	return nil
+}
+
+// sameResult reports whether two results have the same score.
+func sameResult(a, b *models.GameResult) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.GamesWon == b.GamesWon && a.GamesLost == b.GamesLost
+}
+
 // sendNotification sends an email notification for a game to the team's recipients.
 // Returns nil on success (or when there are no recipients to notify), or an error if
 // the send failed. Callers should only mark the game notified when this returns nil.
@@ -145,31 +359,149 @@ func (p *Poller) sendNotification(game models.Game) error {
 		return nil
 	}
 
-	recipients, err := p.storage.GetActiveRecipientsForTeam(game.League, game.TeamKey)
-	if err != nil {
-		log.Printf("Error getting recipients for %s/%s: %v", game.League, game.TeamKey, err)
+	emails, err := p.recipientEmails(game.League, game.TeamKey)
+	if err != nil || len(emails) == 0 {
+		return err
+	}
+
+	if err := p.notifier.SendNotification(game, emails); err != nil {
+		log.Printf("Error sending %s notification for game %s: %v", p.notifier.GetType(), game.ID, err)
 		return err
 	}
+	log.Printf("Sent %s notification for %s game on %s at %s",
+		p.notifier.GetType(), game.League, game.Date.Format("Jan 2"), game.Clock())
+	return nil
+}
+
+// sendResultNotification emails the team that a game's result was posted.
+// Failures are logged and not retried.
+func (p *Poller) sendResultNotification(game models.Game) {
+	if p.notifier == nil {
+		return
+	}
+
+	emails, err := p.recipientEmails(game.League, game.TeamKey)
+	if err != nil || len(emails) == 0 {
+		return
+	}
+
+	if err := p.notifier.SendResultNotification(game, emails); err != nil {
+		log.Printf("Error sending %s result notification for game %s: %v", p.notifier.GetType(), game.ID, err)
+		return
+	}
+	log.Printf("Sent %s result notification for %s game on %s", p.notifier.GetType(), game.League, game.Date.Format("Jan 2"))
+}
+
+// recipientEmails returns the active recipients' addresses for a team.
+func (p *Poller) recipientEmails(leagueName, teamKey string) ([]string, error) {
+	recipients, err := p.storage.GetActiveRecipientsForTeam(leagueName, teamKey)
+	if err != nil {
+		log.Printf("Error getting recipients for %s/%s: %v", leagueName, teamKey, err)
+		return nil, err
+	}
 
 	if len(recipients) == 0 {
-		log.Printf("No active recipients for %s/%s, skipping notification", game.League, game.TeamKey)
-		return nil
+		log.Printf("No active recipients for %s/%s, skipping notification", leagueName, teamKey)
+		return nil, nil
 	}
 
 	var emails []string
 	for _, r := range recipients {
 		emails = append(emails, r.Email)
 	}
+	return emails, nil
+}
 
-	if err := p.notifier.SendNotification(game, emails); err != nil {
-		log.Printf("Error sending %s notification for game %s: %v", p.notifier.GetType(), game.ID, err)
+// announceSeasons sends each team one email with the full schedule of a newly
+// posted season (league.SeasonProvider), instead of one email per game. Once
+// announced, the season's games are saved and, in immediate mode, marked
+// notified. Games of a season whose announcement failed are returned so the
+// caller holds them back until a later poll announces the season.
+func (p *Poller) announceSeasons(lg league.League) map[string]bool {
+	provider, ok := lg.(league.SeasonProvider)
+	if !ok {
+		return nil
+	}
+
+	held := make(map[string]bool)
+	hold := func(season models.Season) {
+		for _, game := range season.Games {
+			held[scopedGameKey(game)] = true
+		}
+	}
+
+	for _, season := range provider.UpcomingSeasons() {
+		announced, err := p.storage.IsSeasonAnnounced(season.League, season.TeamKey, season.ScheduleID)
+		if err != nil {
+			log.Printf("Error checking season announcement for %s/%s: %v", season.League, season.TeamKey, err)
+			hold(season)
+			continue
+		}
+		if announced {
+			continue
+		}
+
+		if err := p.sendSeasonNotification(season); err != nil {
+			hold(season)
+			continue
+		}
+
+		for _, game := range season.Games {
+			if _, err := p.saveNewGame(lg, game); err != nil {
+				log.Printf("Error saving game %s: %v", game.ID, err)
+				continue
+			}
+			// Daily reminders still go out on game day.
+			if lg.NotifyMode() == league.NotifyImmediate {
+				if err := p.storage.MarkGameNotified(game); err != nil {
+					log.Printf("Error marking game as notified: %v", err)
+				}
+			}
+		}
+
+		announcement := models.SeasonAnnouncement{
+			League:      season.League,
+			TeamKey:     season.TeamKey,
+			ScheduleID:  season.ScheduleID,
+			Name:        season.Name,
+			GameCount:   len(season.Games),
+			AnnouncedAt: time.Now(),
+		}
+		if err := p.storage.SaveSeasonAnnouncement(announcement); err != nil {
+			log.Printf("Error saving season announcement: %v", err)
+			continue
+		}
+		log.Printf("%s/%s: announced new season %q with %d games", lg.DisplayName(), season.TeamKey, season.Name, len(season.Games))
+	}
+
+	return held
+}
+
+// sendSeasonNotification emails a team a newly posted season's schedule.
+// Returns nil on success or when there is no one to notify.
+func (p *Poller) sendSeasonNotification(season models.Season) error {
+	if p.notifier == nil {
+		return nil
+	}
+
+	emails, err := p.recipientEmails(season.League, season.TeamKey)
+	if err != nil || len(emails) == 0 {
 		return err
 	}
-	log.Printf("Sent %s notification for %s game on %s at %s",
-		p.notifier.GetType(), game.League, game.Date.Format("Jan 2"), game.Time)
+
+	if err := p.notifier.SendSeasonNotification(season, emails); err != nil {
+		log.Printf("Error sending %s season notification for %s/%s: %v", p.notifier.GetType(), season.League, season.TeamKey, err)
+		return err
+	}
+	log.Printf("Sent %s season notification for %s/%s (%s)", p.notifier.GetType(), season.League, season.TeamKey, season.Name)
 	return nil
 }
 
+// scopedGameKey identifies a game across a league's teams.
+func scopedGameKey(game models.Game) string {
+	return game.TeamKey + ":" + game.ID
+}
+
 // maybeSaveSnapshot hashes the league's upstream payload, compares to the
 // latest stored snapshot, and saves a new one only when the schedule has
 // actually changed. Prefers raw upstream data (via league.RawDataProvider)
@@ -214,6 +546,47 @@ func (p *Poller) maybeSaveSnapshot(lg league.League, teamGames map[string][]mode
 	log.Printf("%s: schedule changed, saved new snapshot %s", lg.DisplayName(), snapshot.ID)
 }
 
+// maybeSaveStandings stores the league's standings when they differ from the
+// latest stored ones, building up a history of how they changed.
+func (p *Poller) maybeSaveStandings(lg league.League) {
+	provider, ok := lg.(league.StandingsProvider)
+	if !ok {
+		return
+	}
+	standings := provider.LastStandings()
+	if len(standings) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(standings)
+	if err != nil {
+		log.Printf("Error hashing standings: %v", err)
+		return
+	}
+	dataHash := fmt.Sprintf("%x", sha256.Sum256(data))
+
+	latest, err := p.storage.GetLatestStandings(lg.Name())
+	if err != nil {
+		log.Printf("Error reading standings for %s: %v", lg.DisplayName(), err)
+		return
+	}
+	if latest != nil && latest.Hash == dataHash {
+		return
+	}
+
+	snapshot := models.StandingsSnapshot{
+		League:    lg.Name(),
+		Standings: standings,
+		Hash:      dataHash,
+		FetchedAt: time.Now(),
+	}
+	if err := p.storage.SaveStandings(snapshot); err != nil {
+		log.Printf("Error saving standings: %v", err)
+		return
+	}
+	log.Printf("%s: standings changed, saved %d team(s)", lg.DisplayName(), len(standings))
+}
+
 func hashGames(games []models.Game) string {
 	var data string
 	for _, g := range games {
@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aweist/schedule-watcher/league"
+	"github.com/aweist/schedule-watcher/models"
+	"github.com/aweist/schedule-watcher/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowLeague takes delay to fetch, or until its context is done, and counts
+// how many fetches of the leagues sharing inFlight overlap.
+type slowLeague struct {
+	name     string
+	delay    time.Duration
+	inFlight *gauge
+}
+
+func (l *slowLeague) Name() string               { return l.name }
+func (l *slowLeague) DisplayName() string        { return l.name }
+func (l *slowLeague) NotifyMode() string         { return league.NotifyImmediate }
+func (l *slowLeague) ReminderTime() string       { return "" }
+func (l *slowLeague) Teams() []league.TeamConfig { return nil }
+
+func (l *slowLeague) FetchAndParse(ctx context.Context) (map[string][]models.Game, error) {
+	l.inFlight.add(1)
+	defer l.inFlight.add(-1)
+
+	select {
+	case <-time.After(l.delay):
+		return map[string][]models.Game{"team": {{ID: l.name}}}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type gauge struct {
+	mu       sync.Mutex
+	cur, max int
+}
+
+func (g *gauge) add(n int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cur += n
+	if g.cur > g.max {
+		g.max = g.cur
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	inFlight := &gauge{}
+	leagues := []league.League{
+		&slowLeague{name: "a", delay: 40 * time.Millisecond, inFlight: inFlight},
+		&slowLeague{name: "b", delay: 10 * time.Millisecond, inFlight: inFlight},
+		&slowLeague{name: "hung", delay: time.Hour, inFlight: inFlight},
+		&slowLeague{name: "c", delay: 20 * time.Millisecond, inFlight: inFlight},
+	}
+	p := NewPoller(PollerConfig{LeagueTimeout: 200 * time.Millisecond, MaxConcurrent: 2})
+
+	start := time.Now()
+	results := p.fetchAll(context.Background(), leagues)
+	for i, name := range []string{"a", "b", "hung", "c"} {
+		res := <-results[i]
+		if name == "hung" {
+			require.Error(t, res.err)
+			assert.ErrorIs(t, res.err, context.DeadlineExceeded)
+			assert.Contains(t, res.err.Error(), "timed out after 200ms")
+			continue
+		}
+		require.NoError(t, res.err)
+		assert.Equal(t, name, res.teamGames["team"][0].ID, "results line up with the leagues")
+	}
+	assert.Less(t, time.Since(start), 2*time.Second, "a hung league only costs its timeout")
+	assert.Equal(t, 2, inFlight.max, "no more than MaxConcurrent fetches at once")
+}
+
+func TestFetchAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	leagues := []league.League{
+		&slowLeague{name: "a", delay: time.Hour, inFlight: &gauge{}},
+		&slowLeague{name: "b", delay: time.Hour, inFlight: &gauge{}},
+	}
+	p := NewPoller(PollerConfig{MaxConcurrent: 1})
+	for _, ch := range p.fetchAll(ctx, leagues) {
+		res := <-ch
+		assert.ErrorIs(t, res.err, context.Canceled, "every league still gets a result")
+	}
+}
+
+// fixedLeague returns the same games and error from every fetch.
+type fixedLeague struct {
+	games map[string][]models.Game
+	err   error
+}
+
+func (l *fixedLeague) Name() string         { return "fixed" }
+func (l *fixedLeague) DisplayName() string  { return "Fixed" }
+func (l *fixedLeague) NotifyMode() string   { return league.NotifyDailyReminder }
+func (l *fixedLeague) ReminderTime() string { return "08:00" }
+func (l *fixedLeague) Teams() []league.TeamConfig {
+	return []league.TeamConfig{{Key: "a"}, {Key: "b"}}
+}
+
+func (l *fixedLeague) FetchAndParse(ctx context.Context) (map[string][]models.Game, error) {
+	return l.games, l.err
+}
+
+func TestPollPartialFailure(t *testing.T) {
+	db, err := storage.NewBoltStorage(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	game := models.Game{ID: "g1", League: "fixed", TeamKey: "a", Date: time.Now().AddDate(0, 0, 7)}
+	lg := &fixedLeague{
+		games: map[string][]models.Game{"a": {game}},
+		err:   &league.PartialError{Err: errors.New("team b: no such team")},
+	}
+	p := NewPoller(PollerConfig{Leagues: []league.League{lg}, Storage: db, Notifier: &alertRecorder{}})
+	p.poll(context.Background())
+
+	// The team that was fetched is saved, but the poll counts as failed.
+	saved, err := db.GetGame("fixed", "a", "g1")
+	require.NoError(t, err)
+	assert.NotNil(t, saved)
+
+	health, err := db.GetHealth("fixed")
+	require.NoError(t, err)
+	require.NotNil(t, health)
+	assert.Equal(t, 1, health.ConsecutiveFailures)
+	assert.Contains(t, health.LastError, "team b")
+
+	// So is a poll where no team could be fetched.
+	lg.games, lg.err = nil, errors.New("no team could be fetched")
+	p.poll(context.Background())
+	health, err = db.GetHealth("fixed")
+	require.NoError(t, err)
+	assert.Equal(t, 2, health.ConsecutiveFailures)
+}
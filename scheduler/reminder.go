@@ -1,7 +1,9 @@
 package scheduler
 
 import (
+	"context"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/aweist/schedule-watcher/league"
@@ -12,6 +14,7 @@ import (
 // DailyReminder sends game-day reminders for leagues with notify_mode "daily_reminder".
 // It checks once per minute and fires at the configured reminder_time each day.
 type DailyReminder struct {
+	mu       sync.RWMutex
 	leagues  []league.League
 	storage  *storage.BoltStorage
 	notifier notifier.Notifier
@@ -25,38 +28,73 @@ func NewDailyReminder(leagues []league.League, store *storage.BoltStorage, n not
 	}
 }
 
-func (d *DailyReminder) Start() {
-	// Filter to only daily_reminder leagues
+// SetLeagues swaps the leagues checked for reminders from the next tick onwards.
+func (d *DailyReminder) SetLeagues(leagues []league.League) {
+	d.mu.Lock()
+	d.leagues = leagues
+	d.mu.Unlock()
+	d.logEnabled()
+}
+
+// reminderLeagues returns the current leagues with notify_mode "daily_reminder".
+func (d *DailyReminder) reminderLeagues() []league.League {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
 	var reminderLeagues []league.League
 	for _, lg := range d.leagues {
 		if lg.NotifyMode() == league.NotifyDailyReminder {
 			reminderLeagues = append(reminderLeagues, lg)
 		}
 	}
+	return reminderLeagues
+}
 
+func (d *DailyReminder) logEnabled() {
+	reminderLeagues := d.reminderLeagues()
 	if len(reminderLeagues) == 0 {
-		log.Println("No daily_reminder leagues configured, skipping reminder scheduler")
+		log.Println("No daily_reminder leagues configured")
 		return
 	}
-
 	for _, lg := range reminderLeagues {
-		log.Printf("Daily reminders enabled for %s at %s", lg.DisplayName(), lg.ReminderTime())
+		log.Printf("Daily reminders enabled for %s at %s %s", lg.DisplayName(), lg.ReminderTime(), league.Location(lg))
 	}
+}
+
+// Start checks once per minute until ctx is cancelled. It keeps ticking
+// even with no daily_reminder leagues so that leagues added by a config
+// reload are picked up. A check in progress when ctx is cancelled finishes
+// the reminder it is sending and leaves the rest for the next run.
+func (d *DailyReminder) Start(ctx context.Context) {
+	d.logEnabled()
 
 	ticker := time.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		d.check(reminderLeagues)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Daily reminder stopped")
+			return
+		case <-ticker.C:
+			d.check(ctx, d.reminderLeagues())
+		}
 	}
 }
 
-func (d *DailyReminder) check(leagues []league.League) {
+// check sends the reminders that are due. Each league's reminder time and
+// game days are in the league's time zone, so reminders follow its daylight
+// saving changes rather than the server's.
+func (d *DailyReminder) check(ctx context.Context, leagues []league.League) {
 	now := time.Now()
-	currentTime := now.Format("15:04")
-	today := now.Format("2006-01-02")
-
 	for _, lg := range leagues {
+		if ctx.Err() != nil {
+			return
+		}
+		local := now.In(league.Location(lg))
+		currentTime := local.Format("15:04")
+		today := local.Format("2006-01-02")
+
 		// Check if it's time (compare HH:MM)
 		if currentTime < lg.ReminderTime() {
 			continue
@@ -65,11 +103,11 @@ func (d *DailyReminder) check(leagues []league.League) {
 		// Per-game IsGameNotified check in sendRemindersForToday prevents
 		// duplicate sends; unnotified games (e.g., from a prior SMTP failure)
 		// will retry on the next tick.
-		d.sendRemindersForToday(lg, today)
+		d.sendRemindersForToday(ctx, lg, today, local.Location())
 	}
 }
 
-func (d *DailyReminder) sendRemindersForToday(lg league.League, today string) {
+func (d *DailyReminder) sendRemindersForToday(ctx context.Context, lg league.League, today string, loc *time.Location) {
 	if d.notifier == nil {
 		return
 	}
@@ -82,7 +120,13 @@ func (d *DailyReminder) sendRemindersForToday(lg league.League, today string) {
 		}
 
 		for _, game := range games {
+			if ctx.Err() != nil {
+				return
+			}
 			gameDate := game.Date.Format("2006-01-02")
+			if !game.StartsAt.IsZero() {
+				gameDate = game.StartsAt.In(loc).Format("2006-01-02")
+			}
 			if gameDate != today {
 				continue
 			}
@@ -113,7 +157,7 @@ func (d *DailyReminder) sendRemindersForToday(lg league.League, today string) {
 				continue
 			}
 			log.Printf("Sent daily reminder for %s: %s at %s on Court %s",
-				lg.DisplayName(), game.Date.Format("Jan 2"), game.Time, game.Court)
+				lg.DisplayName(), game.Date.Format("Jan 2"), game.Clock(), game.Court)
 
 			// Mark as notified so we don't remind again
 			if err := d.storage.MarkGameNotified(game); err != nil {
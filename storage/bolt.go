@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +17,9 @@ const (
 	bucketNotified   = "notified"
 	bucketRecipients = "recipients"
 	bucketSnapshots  = "snapshots"
+	bucketStandings  = "standings"
+	bucketSeasons    = "seasons"
+	bucketHealth     = "health"
 	bucketMeta       = "_meta"
 )
 
@@ -30,7 +34,7 @@ func NewBoltStorage(dbPath string) (*BoltStorage, error) {
 	}
 
 	err = db.Update(func(tx *bolt.Tx) error {
-		for _, bucket := range []string{bucketGames, bucketNotified, bucketRecipients, bucketSnapshots, bucketMeta} {
+		for _, bucket := range []string{bucketGames, bucketNotified, bucketRecipients, bucketSnapshots, bucketStandings, bucketSeasons, bucketHealth, bucketMeta} {
 			if _, err := tx.CreateBucketIfNotExists([]byte(bucket)); err != nil {
 				return fmt.Errorf("creating %s bucket: %w", bucket, err)
 			}
@@ -377,15 +381,196 @@ func (s *BoltStorage) GetAllSnapshots() ([]models.Snapshot, error) {
 	return snapshots, err
 }
 
+// ReadSnapshots returns a league's snapshots from the database at dbPath,
+// oldest first. The database is opened read-only; a running server holds
+// its own database locked, so point this at a copy.
+func ReadSnapshots(dbPath, league string) ([]models.Snapshot, error) {
+	db, err := bolt.Open(dbPath, 0600, &bolt.Options{Timeout: 1 * time.Second, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	prefix := league + ":"
+	var snapshots []models.Snapshot
+	err = db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketSnapshots))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Seek([]byte(prefix)); k != nil && strings.HasPrefix(string(k), prefix); k, v = c.Next() {
+			var snap models.Snapshot
+			if err := json.Unmarshal(v, &snap); err != nil {
+				return fmt.Errorf("decoding snapshot %s: %w", k, err)
+			}
+			snapshots = append(snapshots, snap)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].FetchedAt.Before(snapshots[j].FetchedAt)
+	})
+	return snapshots, nil
+}
+
+// --- Standings ---
+
+// standingsKey creates a key in the format "league:timestamp", with a
+// timestamp that sorts lexically in time order.
+func standingsKey(league string, fetchedAt time.Time) string {
+	return fmt.Sprintf("%s:%s", league, fetchedAt.UTC().Format("20060102T150405.000000000Z"))
+}
+
+// SaveStandings adds a standings snapshot to the league's history.
+func (s *BoltStorage) SaveStandings(snapshot models.StandingsSnapshot) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketStandings))
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return fmt.Errorf("marshaling standings: %w", err)
+		}
+		return b.Put([]byte(standingsKey(snapshot.League, snapshot.FetchedAt)), data)
+	})
+}
+
+// GetLatestStandings returns the league's most recent standings, or nil if
+// none have been stored.
+func (s *BoltStorage) GetLatestStandings(league string) (*models.StandingsSnapshot, error) {
+	prefix := league + ":"
+	var latest *models.StandingsSnapshot
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucketStandings)).Cursor()
+		var last []byte
+		for k, v := c.Seek([]byte(prefix)); k != nil && strings.HasPrefix(string(k), prefix); k, v = c.Next() {
+			last = v
+		}
+		if last == nil {
+			return nil
+		}
+		latest = &models.StandingsSnapshot{}
+		return json.Unmarshal(last, latest)
+	})
+
+	return latest, err
+}
+
+// GetStandingsHistory returns every stored standings snapshot for the
+// league, oldest first.
+func (s *BoltStorage) GetStandingsHistory(league string) ([]models.StandingsSnapshot, error) {
+	prefix := league + ":"
+	var history []models.StandingsSnapshot
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucketStandings)).Cursor()
+		for k, v := c.Seek([]byte(prefix)); k != nil && strings.HasPrefix(string(k), prefix); k, v = c.Next() {
+			var snap models.StandingsSnapshot
+			if err := json.Unmarshal(v, &snap); err != nil {
+				return err
+			}
+			history = append(history, snap)
+		}
+		return nil
+	})
+
+	return history, err
+}
+
+// --- Season Announcements ---
+
+func (s *BoltStorage) IsSeasonAnnounced(league, teamKey, scheduleID string) (bool, error) {
+	var exists bool
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketSeasons))
+		exists = b.Get([]byte(scopedKey(league, teamKey, scheduleID))) != nil
+		return nil
+	})
+
+	return exists, err
+}
+
+func (s *BoltStorage) SaveSeasonAnnouncement(announcement models.SeasonAnnouncement) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketSeasons))
+		data, err := json.Marshal(announcement)
+		if err != nil {
+			return fmt.Errorf("marshaling season announcement: %w", err)
+		}
+		key := scopedKey(announcement.League, announcement.TeamKey, announcement.ScheduleID)
+		return b.Put([]byte(key), data)
+	})
+}
+
+func (s *BoltStorage) GetAllSeasonAnnouncements() ([]models.SeasonAnnouncement, error) {
+	var announcements []models.SeasonAnnouncement
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketSeasons))
+		return b.ForEach(func(k, v []byte) error {
+			var a models.SeasonAnnouncement
+			if err := json.Unmarshal(v, &a); err != nil {
+				return err
+			}
+			announcements = append(announcements, a)
+			return nil
+		})
+	})
+
+	return announcements, err
+}
+
+// --- Health ---
+
+// healthKey creates a key in the format "league:health"
+func healthKey(league string) string {
+	return league + ":health"
+}
+
+// SaveHealth stores a league's health record, replacing the previous one.
+func (s *BoltStorage) SaveHealth(health models.LeagueHealth) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketHealth))
+		data, err := json.Marshal(health)
+		if err != nil {
+			return fmt.Errorf("marshaling health: %w", err)
+		}
+		return b.Put([]byte(healthKey(health.League)), data)
+	})
+}
+
+// GetHealth returns the league's health record, or nil if it hasn't been
+// polled yet.
+func (s *BoltStorage) GetHealth(league string) (*models.LeagueHealth, error) {
+	var health *models.LeagueHealth
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket([]byte(bucketHealth)).Get([]byte(healthKey(league)))
+		if data == nil {
+			return nil
+		}
+		health = &models.LeagueHealth{}
+		return json.Unmarshal(data, health)
+	})
+
+	return health, err
+}
+
 // --- Cleanup ---
 
-// CleanupStaleData removes games, notifications, and snapshots for league/team
-// combos that are no longer in the config. validTeams is a set of "league:teamKey" strings.
+// CleanupStaleData removes games, notifications, season announcements,
+// snapshots, standings and health for league/team combos that are no longer
+// in the config. validTeams is a set of "league:teamKey" strings.
 func (s *BoltStorage) CleanupStaleData(validTeams map[string]bool) error {
 	return s.db.Update(func(tx *bolt.Tx) error {
 		deleted := 0
 
-		for _, bucketName := range []string{bucketGames, bucketNotified, bucketRecipients} {
+		for _, bucketName := range []string{bucketGames, bucketNotified, bucketRecipients, bucketSeasons} {
 			b := tx.Bucket([]byte(bucketName))
 			var staleKeys [][]byte
 
@@ -411,31 +596,33 @@ func (s *BoltStorage) CleanupStaleData(validTeams map[string]bool) error {
 			}
 		}
 
-		// Snapshots use "league:id" format — check league prefix
+		// Snapshots, standings and health use "league:id" format — check league prefix
 		validLeagues := make(map[string]bool)
 		for scope := range validTeams {
 			parts := strings.SplitN(scope, ":", 2)
 			validLeagues[parts[0]] = true
 		}
 
-		snapBucket := tx.Bucket([]byte(bucketSnapshots))
-		var staleSnapKeys [][]byte
-		snapBucket.ForEach(func(k, v []byte) error {
-			key := string(k)
-			parts := strings.SplitN(key, ":", 2)
-			if len(parts) < 2 {
+		for _, bucketName := range []string{bucketSnapshots, bucketStandings, bucketHealth} {
+			b := tx.Bucket([]byte(bucketName))
+			var staleKeys [][]byte
+			b.ForEach(func(k, v []byte) error {
+				key := string(k)
+				parts := strings.SplitN(key, ":", 2)
+				if len(parts) < 2 {
+					return nil
+				}
+				if !validLeagues[parts[0]] {
+					staleKeys = append(staleKeys, append([]byte(nil), k...))
+				}
 				return nil
+			})
+			for _, k := range staleKeys {
+				if err := b.Delete(k); err != nil {
+					return err
+				}
+				deleted++
 			}
-			if !validLeagues[parts[0]] {
-				staleSnapKeys = append(staleSnapKeys, append([]byte(nil), k...))
-			}
-			return nil
-		})
-		for _, k := range staleSnapKeys {
-			if err := snapBucket.Delete(k); err != nil {
-				return err
-			}
-			deleted++
 		}
 
 		if deleted > 0 {
@@ -548,3 +735,167 @@ func migrateBucket(tx *bolt.Tx, bucketName, league, teamKey string) error {
 
 	return nil
 }
+
+// MigrateGameTimes fills in StartsAt and EndsAt on stored games saved
+// before games carried them. fill sets them on a game and reports whether it
+// could; games it can't place are left for a later run. It returns how many
+// games were updated.
+func (s *BoltStorage) MigrateGameTimes(fill func(game *models.Game) bool) (int, error) {
+	updated := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucketGames))
+
+		var keys [][]byte
+		var games []models.Game
+		err := b.ForEach(func(k, v []byte) error {
+			var game models.Game
+			if err := json.Unmarshal(v, &game); err != nil {
+				return nil // skip records we can't read
+			}
+			if game.StartsAt.IsZero() && fill(&game) {
+				keys = append(keys, append([]byte(nil), k...))
+				games = append(games, game)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, k := range keys {
+			data, err := json.Marshal(games[i])
+			if err != nil {
+				return fmt.Errorf("marshaling game: %w", err)
+			}
+			if err := b.Put(k, data); err != nil {
+				return err
+			}
+		}
+		updated = len(keys)
+		return nil
+	})
+	return updated, err
+}
+
+// HasLeagueData reports whether any games, notifications, recipients,
+// season announcements, snapshots, standings or health records are stored
+// under the league namespace.
+func (s *BoltStorage) HasLeagueData(league string) (bool, error) {
+	prefix := []byte(league + ":")
+	found := false
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		for _, bucketName := range []string{bucketGames, bucketNotified, bucketRecipients, bucketSeasons, bucketSnapshots, bucketStandings, bucketHealth} {
+			k, _ := tx.Bucket([]byte(bucketName)).Cursor().Seek(prefix)
+			if k != nil && strings.HasPrefix(string(k), string(prefix)) {
+				found = true
+				return nil
+			}
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+// RenameLeague moves every record stored under the from namespace to the to
+// namespace, rewriting both the keys and the league field inside each value.
+// It returns the number of records moved.
+func (s *BoltStorage) RenameLeague(from, to string) (int, error) {
+	moved := 0
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		rewrites := map[string]func([]byte) ([]byte, error){
+			bucketGames: func(v []byte) ([]byte, error) {
+				var g models.Game
+				if err := json.Unmarshal(v, &g); err != nil {
+					return nil, err
+				}
+				g.League = to
+				return json.Marshal(g)
+			},
+			bucketNotified: func(v []byte) ([]byte, error) {
+				var n models.NotifiedGame
+				if err := json.Unmarshal(v, &n); err != nil {
+					return nil, err
+				}
+				n.League = to
+				return json.Marshal(n)
+			},
+			bucketRecipients: func(v []byte) ([]byte, error) {
+				var r models.EmailRecipient
+				if err := json.Unmarshal(v, &r); err != nil {
+					return nil, err
+				}
+				r.League = to
+				return json.Marshal(r)
+			},
+			bucketSeasons: func(v []byte) ([]byte, error) {
+				var a models.SeasonAnnouncement
+				if err := json.Unmarshal(v, &a); err != nil {
+					return nil, err
+				}
+				a.League = to
+				return json.Marshal(a)
+			},
+			bucketSnapshots: func(v []byte) ([]byte, error) {
+				var snap models.Snapshot
+				if err := json.Unmarshal(v, &snap); err != nil {
+					return nil, err
+				}
+				snap.League = to
+				return json.Marshal(snap)
+			},
+			bucketStandings: func(v []byte) ([]byte, error) {
+				var snap models.StandingsSnapshot
+				if err := json.Unmarshal(v, &snap); err != nil {
+					return nil, err
+				}
+				snap.League = to
+				for i := range snap.Standings {
+					snap.Standings[i].League = to
+				}
+				return json.Marshal(snap)
+			},
+			bucketHealth: func(v []byte) ([]byte, error) {
+				var h models.LeagueHealth
+				if err := json.Unmarshal(v, &h); err != nil {
+					return nil, err
+				}
+				h.League = to
+				return json.Marshal(h)
+			},
+		}
+
+		prefix := from + ":"
+		for bucketName, rewrite := range rewrites {
+			b := tx.Bucket([]byte(bucketName))
+
+			var oldKeys [][]byte
+			var newValues [][]byte
+			c := b.Cursor()
+			for k, v := c.Seek([]byte(prefix)); k != nil && strings.HasPrefix(string(k), prefix); k, v = c.Next() {
+				data, err := rewrite(v)
+				if err != nil {
+					return fmt.Errorf("rewriting %s/%s: %w", bucketName, k, err)
+				}
+				oldKeys = append(oldKeys, append([]byte(nil), k...))
+				newValues = append(newValues, data)
+			}
+
+			for i, k := range oldKeys {
+				newKey := to + ":" + strings.TrimPrefix(string(k), prefix)
+				if err := b.Put([]byte(newKey), newValues[i]); err != nil {
+					return err
+				}
+				if err := b.Delete(k); err != nil {
+					return err
+				}
+				moved++
+			}
+		}
+		return nil
+	})
+
+	return moved, err
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aweist/schedule-watcher/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStorage(t *testing.T) *BoltStorage {
+	t.Helper()
+	s, err := NewBoltStorage(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestHasLeagueDataHealthOnly(t *testing.T) {
+	s := newTestStorage(t)
+
+	// A league that has only ever failed has nothing stored but its health.
+	require.NoError(t, s.SaveHealth(models.LeagueHealth{League: "pins", ConsecutiveFailures: 1}))
+	has, err := s.HasLeagueData("pins")
+	require.NoError(t, err)
+	assert.True(t, has)
+}
+
+func TestRenameLeague(t *testing.T) {
+	s := newTestStorage(t)
+
+	game := models.Game{ID: "g1", League: "ivp", TeamKey: "ts", Date: time.Now()}
+	require.NoError(t, s.SaveGame(game))
+	require.NoError(t, s.MarkGameNotified(game))
+	require.NoError(t, s.AddRecipientForTeam("ivp", "ts", models.EmailRecipient{ID: "r1", Email: "a@example.com", IsActive: true}))
+	require.NoError(t, s.SaveSnapshot(models.Snapshot{ID: "snap-1", League: "ivp", Hash: "abc", FetchedAt: time.Now()}))
+	require.NoError(t, s.SaveHealth(models.LeagueHealth{League: "ivp", ConsecutiveFailures: 2}))
+	// A league whose name shares a prefix must not be touched.
+	require.NoError(t, s.SaveGame(models.Game{ID: "g2", League: "ivp-thursday", TeamKey: "ts"}))
+
+	moved, err := s.RenameLeague("ivp", "ivp-tuesday")
+	require.NoError(t, err)
+	assert.Equal(t, 5, moved)
+
+	hasOld, err := s.HasLeagueData("ivp")
+	require.NoError(t, err)
+	assert.False(t, hasOld)
+
+	moved2, err := s.GetGame("ivp-tuesday", "ts", "g1")
+	require.NoError(t, err)
+	require.NotNil(t, moved2)
+	assert.Equal(t, "ivp-tuesday", moved2.League)
+
+	notified, err := s.IsGameNotified("ivp-tuesday", "ts", "g1")
+	require.NoError(t, err)
+	assert.True(t, notified)
+
+	recipients, err := s.GetActiveRecipientsForTeam("ivp-tuesday", "ts")
+	require.NoError(t, err)
+	require.Len(t, recipients, 1)
+	assert.Equal(t, "ivp-tuesday", recipients[0].League)
+
+	hash, err := s.GetLatestSnapshotHash("ivp-tuesday")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", hash)
+
+	health, err := s.GetHealth("ivp-tuesday")
+	require.NoError(t, err)
+	require.NotNil(t, health)
+	assert.Equal(t, "ivp-tuesday", health.League)
+	assert.Equal(t, 2, health.ConsecutiveFailures)
+
+	other, err := s.GetGame("ivp-thursday", "ts", "g2")
+	require.NoError(t, err)
+	assert.NotNil(t, other)
+}
+
+func TestStandingsHistory(t *testing.T) {
+	s := newTestStorage(t)
+
+	latest, err := s.GetLatestStandings("ivp")
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	first := time.Date(2026, 4, 2, 22, 0, 0, 0, time.UTC)
+	require.NoError(t, s.SaveStandings(models.StandingsSnapshot{
+		League:    "ivp",
+		Hash:      "h1",
+		FetchedAt: first,
+		Standings: []models.Standing{{League: "ivp", TeamName: "Taylor Sisneros", Wins: 1, Rank: 3}},
+	}))
+	require.NoError(t, s.SaveStandings(models.StandingsSnapshot{
+		League:    "ivp",
+		Hash:      "h2",
+		FetchedAt: first.Add(7 * 24 * time.Hour),
+		Standings: []models.Standing{{League: "ivp", TeamName: "Taylor Sisneros", Wins: 2, Rank: 2}},
+	}))
+	require.NoError(t, s.SaveStandings(models.StandingsSnapshot{League: "ivp-thursday", Hash: "other", FetchedAt: first.Add(30 * 24 * time.Hour)}))
+
+	latest, err = s.GetLatestStandings("ivp")
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, "h2", latest.Hash)
+
+	history, err := s.GetStandingsHistory("ivp")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "h1", history[0].Hash)
+	assert.Equal(t, 2, history[1].Standings[0].Wins)
+
+	// Standings follow the league through cleanup and renames.
+	require.NoError(t, s.CleanupStaleData(map[string]bool{"ivp:ts": true}))
+	gone, err := s.GetLatestStandings("ivp-thursday")
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+
+	_, err = s.RenameLeague("ivp", "ivp-tuesday")
+	require.NoError(t, err)
+	renamed, err := s.GetStandingsHistory("ivp-tuesday")
+	require.NoError(t, err)
+	require.Len(t, renamed, 2)
+	assert.Equal(t, "ivp-tuesday", renamed[0].Standings[0].League)
+}
+
+func TestSeasonAnnouncements(t *testing.T) {
+	s := newTestStorage(t)
+
+	announced, err := s.IsSeasonAnnounced("pins", "sets", "170")
+	require.NoError(t, err)
+	assert.False(t, announced)
+
+	require.NoError(t, s.SaveSeasonAnnouncement(models.SeasonAnnouncement{
+		League: "pins", TeamKey: "sets", ScheduleID: "170", Name: "Tue Night Jun-Aug 2026 Season", GameCount: 8, AnnouncedAt: time.Now(),
+	}))
+
+	announced, err = s.IsSeasonAnnounced("pins", "sets", "170")
+	require.NoError(t, err)
+	assert.True(t, announced)
+
+	// Announcements are per team.
+	announced, err = s.IsSeasonAnnounced("pins", "other", "170")
+	require.NoError(t, err)
+	assert.False(t, announced)
+
+	require.NoError(t, s.CleanupStaleData(map[string]bool{"pins:other": true}))
+	all, err := s.GetAllSeasonAnnouncements()
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestMigrateGameTimes(t *testing.T) {
+	s := newTestStorage(t)
+
+	date := time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 3, 17, 21, 40, 0, 0, time.UTC)
+	require.NoError(t, s.SaveGame(models.Game{ID: "old", League: "pins", TeamKey: "sets", Date: date, Time: "9:40"}))
+	require.NoError(t, s.SaveGame(models.Game{ID: "tbd", League: "pins", TeamKey: "sets", Date: date, Time: "TBD"}))
+	require.NoError(t, s.SaveGame(models.Game{ID: "new", League: "pins", TeamKey: "sets", Date: date, Time: "7:55", StartsAt: start}))
+
+	var seen []string
+	fill := func(g *models.Game) bool {
+		seen = append(seen, g.ID)
+		if g.Time != "9:40" {
+			return false
+		}
+		g.StartsAt, g.EndsAt = start, start.Add(55*time.Minute)
+		return true
+	}
+
+	updated, err := s.MigrateGameTimes(fill)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.ElementsMatch(t, []string{"old", "tbd"}, seen)
+
+	g, err := s.GetGame("pins", "sets", "old")
+	require.NoError(t, err)
+	assert.True(t, g.StartsAt.Equal(start))
+	assert.True(t, g.EndsAt.Equal(start.Add(55*time.Minute)))
+
+	// Filled games aren't offered again.
+	seen = nil
+	updated, err = s.MigrateGameTimes(fill)
+	require.NoError(t, err)
+	assert.Equal(t, 0, updated)
+	assert.Equal(t, []string{"tbd"}, seen)
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"crypto/rand"
 	"embed"
 	"encoding/hex"
@@ -13,8 +14,10 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/aweist/schedule-watcher/client"
 	"github.com/aweist/schedule-watcher/league"
 	"github.com/aweist/schedule-watcher/models"
 	"github.com/aweist/schedule-watcher/notifier"
@@ -27,11 +30,17 @@ var templates embed.FS
 //go:embed static/*
 var staticFiles embed.FS
 
+// shutdownTimeout is how long Start waits for requests in progress (a test
+// email, a config reload) when shutting down.
+const shutdownTimeout = 10 * time.Second
+
 type Server struct {
 	storage   *storage.BoltStorage
 	notifier  notifier.Notifier
 	port      string
+	mu        sync.RWMutex
 	leagues   []league.League
+	reloader  func() ([]string, error)
 }
 
 type LeagueTeam struct {
@@ -44,20 +53,54 @@ type PageData struct {
 	Games         []models.Game
 	NotifiedGames []models.NotifiedGame
 	CurrentTime   string
-	CurrentDate   string
 	Now           time.Time
 	Leagues       []league.League
+	Health        []models.LeagueHealth
 }
 
 type AdminPageData struct {
 	Recipients  []models.EmailRecipient
 	LeagueTeams []LeagueTeam
+	LeagueTypes []league.Type
 }
 
 type SnapshotsPageData struct {
 	Snapshots []models.Snapshot
 }
 
+type StandingsPageData struct {
+	Leagues []LeagueStandings
+}
+
+// LeagueStandings is one league's latest standings grouped by division, plus
+// how each tracked team's record has changed over time.
+type LeagueStandings struct {
+	Name        string
+	DisplayName string
+	FetchedAt   time.Time
+	Divisions   []DivisionStandings
+	Tracked     []TrackedStanding
+}
+
+type DivisionStandings struct {
+	Name      string
+	Standings []models.Standing
+}
+
+// TrackedStanding is a tracked team's current standing and its history,
+// newest first.
+type TrackedStanding struct {
+	Current models.Standing
+	History []StandingPoint
+}
+
+type StandingPoint struct {
+	FetchedAt time.Time
+	Wins      int
+	Losses    int
+	Rank      int
+}
+
 func NewServer(storage *storage.BoltStorage, port string, leagues []league.League) *Server {
 	return &Server{
 		storage: storage,
@@ -70,33 +113,78 @@ func (s *Server) SetNotifier(n notifier.Notifier) {
 	s.notifier = n
 }
 
-func (s *Server) Start() {
+// SetLeagues swaps the leagues shown in the UI, e.g. after a config reload.
+func (s *Server) SetLeagues(leagues []league.League) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leagues = leagues
+}
+
+func (s *Server) currentLeagues() []league.League {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.leagues
+}
+
+// SetReloader enables the /api/config/reload endpoint. The function re-reads
+// the config and returns a description of each change applied.
+func (s *Server) SetReloader(reload func() ([]string, error)) {
+	s.reloader = reload
+}
+
+// Start serves the web UI until ctx is cancelled, then stops accepting
+// connections and waits up to shutdownTimeout for requests in progress.
+func (s *Server) Start(ctx context.Context) {
+	mux := http.NewServeMux()
 	staticFS, err := fs.Sub(staticFiles, "static")
 	if err != nil {
 		log.Printf("Failed to create static file system: %v", err)
 	}
-	http.Handle("/static/", http.StripPrefix("/static/", noCacheHandler(http.FileServer(http.FS(staticFS)))))
-
-	http.HandleFunc("/", s.handleDebugPage)
-	http.HandleFunc("/admin", s.handleAdminPage)
-	http.HandleFunc("/snapshots", s.handleSnapshotsPage)
-	http.HandleFunc("/api/games", s.handleAPIGames)
-	http.HandleFunc("/api/notified", s.handleAPINotified)
-	http.HandleFunc("/api/game/delete", s.handleDeleteGame)
-	http.HandleFunc("/api/notified/delete", s.handleDeleteNotifiedGame)
-	http.HandleFunc("/api/test-email", s.handleTestEmail)
-	http.HandleFunc("/api/recipients/add", s.handleAddRecipient)
-	http.HandleFunc("/api/recipients/delete", s.handleDeleteRecipient)
-	http.HandleFunc("/api/recipients/toggle", s.handleToggleRecipient)
+	mux.Handle("/static/", http.StripPrefix("/static/", noCacheHandler(http.FileServer(http.FS(staticFS)))))
+
+	mux.HandleFunc("/", s.handleDebugPage)
+	mux.HandleFunc("/admin", s.handleAdminPage)
+	mux.HandleFunc("/snapshots", s.handleSnapshotsPage)
+	mux.HandleFunc("/standings", s.handleStandingsPage)
+	mux.HandleFunc("/api/games", s.handleAPIGames)
+	mux.HandleFunc("/api/notified", s.handleAPINotified)
+	mux.HandleFunc("/api/standings", s.handleAPIStandings)
+	mux.HandleFunc("/api/fetches", s.handleAPIFetches)
+	mux.HandleFunc("/api/health", s.handleAPIHealth)
+	mux.HandleFunc("/api/game/delete", s.handleDeleteGame)
+	mux.HandleFunc("/api/notified/delete", s.handleDeleteNotifiedGame)
+	mux.HandleFunc("/api/test-email", s.handleTestEmail)
+	mux.HandleFunc("/api/recipients/add", s.handleAddRecipient)
+	mux.HandleFunc("/api/recipients/delete", s.handleDeleteRecipient)
+	mux.HandleFunc("/api/recipients/toggle", s.handleToggleRecipient)
+	mux.HandleFunc("/api/config/reload", s.handleReloadConfig)
+
+	srv := &http.Server{Addr: ":" + s.port, Handler: mux}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
 
 	log.Printf("Starting debug web server on http://localhost:%s", s.port)
-	if err := http.ListenAndServe(":"+s.port, nil); err != nil {
+	select {
+	case err := <-errc:
 		log.Printf("Web server error: %v", err)
+		return
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Web server shutdown: %v", err)
+		return
 	}
+	log.Println("Web server stopped")
 }
 
 func (s *Server) handleDebugPage(w http.ResponseWriter, r *http.Request) {
-	tmpl, err := template.ParseFS(templates, "templates/debug.html")
+	tmpl, err := template.New("debug.html").Funcs(template.FuncMap{
+		"gameClass": gameClass,
+		"zone":      zoneAbbrev,
+	}).ParseFS(templates, "templates/debug.html")
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Template error: %v", err), http.StatusInternalServerError)
 		return
@@ -115,21 +203,27 @@ func (s *Server) handleDebugPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	sort.Slice(games, func(i, j int) bool {
-		return games[i].Date.Before(games[j].Date)
+		return games[i].Start().Before(games[j].Start())
 	})
 
 	sort.Slice(notifiedGames, func(i, j int) bool {
 		return notifiedGames[i].NotifiedAt.After(notifiedGames[j].NotifiedAt)
 	})
 
+	health, err := s.leagueHealth()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error fetching health: %v", err), http.StatusInternalServerError)
+		return
+	}
+
 	now := time.Now()
 	data := PageData{
 		Games:         games,
 		NotifiedGames: notifiedGames,
 		CurrentTime:   now.Format("2006-01-02 15:04:05 MST"),
-		CurrentDate:   now.Format("2006-01-02"),
 		Now:           now,
-		Leagues:       s.leagues,
+		Leagues:       s.currentLeagues(),
+		Health:        health,
 	}
 
 	if err := tmpl.Execute(w, data); err != nil {
@@ -243,6 +337,8 @@ func (s *Server) handleTestEmail(w http.ResponseWriter, r *http.Request) {
 		Time:        "7:00 PM",
 		Court:       "Test Court",
 	}
+	testGame.StartsAt, _ = models.ParseClock(testGame.Date, testGame.Time, time.Local, false)
+	testGame.EndsAt = testGame.StartsAt.Add(time.Hour)
 
 	if err := s.notifier.SendNotification(testGame, []string{email}); err != nil {
 		log.Printf("Test email to %s failed: %v", email, err)
@@ -260,6 +356,43 @@ func (s *Server) handleTestEmail(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *Server) handleReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if s.reloader == nil {
+		json.NewEncoder(w).Encode(map[string]string{
+			"status":  "error",
+			"message": "Config reload is not available",
+		})
+		return
+	}
+
+	changes, err := s.reloader()
+	if err != nil {
+		log.Printf("Config reload from web UI failed: %v", err)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":  "error",
+			"message": fmt.Sprintf("Config reload failed: %v", err),
+		})
+		return
+	}
+
+	message := "Config reloaded, no changes"
+	if len(changes) > 0 {
+		message = fmt.Sprintf("Config reloaded with %d change(s):\n%s", len(changes), strings.Join(changes, "\n"))
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "success",
+		"message": message,
+		"changes": changes,
+	})
+}
+
 func (s *Server) handleAdminPage(w http.ResponseWriter, r *http.Request) {
 	tmpl, err := template.ParseFS(templates, "templates/admin.html")
 	if err != nil {
@@ -285,7 +418,7 @@ func (s *Server) handleAdminPage(w http.ResponseWriter, r *http.Request) {
 
 	// Build league/team list for the add form dropdown
 	var leagueTeams []LeagueTeam
-	for _, lg := range s.leagues {
+	for _, lg := range s.currentLeagues() {
 		for _, t := range lg.Teams() {
 			leagueTeams = append(leagueTeams, LeagueTeam{
 				League:   lg.Name(),
@@ -298,6 +431,7 @@ func (s *Server) handleAdminPage(w http.ResponseWriter, r *http.Request) {
 	data := AdminPageData{
 		Recipients:  recipients,
 		LeagueTeams: leagueTeams,
+		LeagueTypes: league.Types(),
 	}
 
 	if err := tmpl.Execute(w, data); err != nil {
@@ -318,10 +452,10 @@ func (s *Server) handleSnapshotsPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Only IVP snapshots carry raw CSV data worth viewing; skip the rest.
+	// Only snapshots that carry raw upstream data are worth viewing; skip the rest.
 	var snapshots []models.Snapshot
 	for _, snap := range allSnapshots {
-		if snap.League == "ivp" {
+		if snap.CSVData != "" {
 			snapshots = append(snapshots, snap)
 		}
 	}
@@ -339,6 +473,189 @@ func (s *Server) handleSnapshotsPage(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleAPIFetches reports the shared fetcher's request counts per host.
+func (s *Server) handleAPIFetches(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(client.Default().Stats())
+}
+
+// handleAPIHealth reports each league's health record, in league order.
+// It responds 503 while any league is failing, so it can back an uptime check.
+func (s *Server) handleAPIHealth(w http.ResponseWriter, r *http.Request) {
+	health, err := s.leagueHealth()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error fetching health: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	for _, h := range health {
+		if !h.Healthy() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			break
+		}
+	}
+	json.NewEncoder(w).Encode(health)
+}
+
+// leagueHealth returns the health record of each current league. Leagues
+// that haven't been polled yet get an empty record.
+func (s *Server) leagueHealth() ([]models.LeagueHealth, error) {
+	health := []models.LeagueHealth{}
+	for _, lg := range s.currentLeagues() {
+		h, err := s.storage.GetHealth(lg.Name())
+		if err != nil {
+			return nil, err
+		}
+		if h == nil {
+			h = &models.LeagueHealth{League: lg.Name()}
+		}
+		h.DisplayName = lg.DisplayName()
+		health = append(health, *h)
+	}
+	return health, nil
+}
+
+// handleAPIStandings returns the latest standings of every league, or with
+// ?league=<namespace> just that league's; adding &history=true returns every
+// stored snapshot for the league, oldest first.
+func (s *Server) handleAPIStandings(w http.ResponseWriter, r *http.Request) {
+	leagueName := r.URL.Query().Get("league")
+
+	if r.URL.Query().Get("history") == "true" {
+		if leagueName == "" {
+			http.Error(w, "league is required for history", http.StatusBadRequest)
+			return
+		}
+		history, err := s.storage.GetStandingsHistory(leagueName)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error fetching standings: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(history)
+		return
+	}
+
+	latest := []models.StandingsSnapshot{}
+	for _, lg := range s.currentLeagues() {
+		if leagueName != "" && lg.Name() != leagueName {
+			continue
+		}
+		snap, err := s.storage.GetLatestStandings(lg.Name())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error fetching standings: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if snap != nil {
+			latest = append(latest, *snap)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(latest)
+}
+
+func (s *Server) handleStandingsPage(w http.ResponseWriter, r *http.Request) {
+	tmpl, err := template.New("standings.html").Funcs(template.FuncMap{
+		"percent": func(v float64) string { return fmt.Sprintf("%.1f%%", v*100) },
+	}).ParseFS(templates, "templates/standings.html")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Template error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	var data StandingsPageData
+	for _, lg := range s.currentLeagues() {
+		history, err := s.storage.GetStandingsHistory(lg.Name())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error fetching standings: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if len(history) == 0 {
+			continue
+		}
+		data.Leagues = append(data.Leagues, buildLeagueStandings(lg, history))
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("Template execution error: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// buildLeagueStandings groups the latest snapshot by division and traces
+// each tracked team back through the history.
+func buildLeagueStandings(lg league.League, history []models.StandingsSnapshot) LeagueStandings {
+	latest := history[len(history)-1]
+	ls := LeagueStandings{
+		Name:        lg.Name(),
+		DisplayName: lg.DisplayName(),
+		FetchedAt:   latest.FetchedAt,
+	}
+
+	for _, st := range latest.Standings {
+		if n := len(ls.Divisions); n == 0 || ls.Divisions[n-1].Name != st.Division {
+			ls.Divisions = append(ls.Divisions, DivisionStandings{Name: st.Division})
+		}
+		div := &ls.Divisions[len(ls.Divisions)-1]
+		div.Standings = append(div.Standings, st)
+
+		if st.TeamKey == "" {
+			continue
+		}
+		tracked := TrackedStanding{Current: st}
+		for i := len(history) - 1; i >= 0; i-- {
+			for _, past := range history[i].Standings {
+				if past.TeamKey == st.TeamKey && past.Division == st.Division {
+					tracked.History = append(tracked.History, StandingPoint{
+						FetchedAt: history[i].FetchedAt,
+						Wins:      past.Wins,
+						Losses:    past.Losses,
+						Rank:      past.Rank,
+					})
+					break
+				}
+			}
+		}
+		ls.Tracked = append(ls.Tracked, tracked)
+	}
+
+	return ls
+}
+
+// gameClass returns the debug page row class of a game: past, today or
+// future, judged by the calendar in the game's time zone.
+func gameClass(game models.Game, now time.Time) string {
+	if game.Date.Unix() <= 0 {
+		return ""
+	}
+	day := game.Date.Format("2006-01-02")
+	today := now.In(game.Location()).Format("2006-01-02")
+	switch {
+	case day < today:
+		return "game-past"
+	case day == today:
+		return "game-today"
+	default:
+		return "game-future"
+	}
+}
+
+// zoneAbbrev returns the abbreviation ("MDT") of a game's time zone when it
+// starts, or "" for games in the server's local zone. Games with no start
+// time use noon, the abbreviation in effect on days the clocks change.
+func zoneAbbrev(game models.Game) string {
+	loc := game.Location()
+	if loc == time.Local {
+		return ""
+	}
+	if !game.StartsAt.IsZero() {
+		return game.StartsAt.In(loc).Format("MST")
+	}
+	noon := time.Date(game.Date.Year(), game.Date.Month(), game.Date.Day(), 12, 0, 0, 0, loc)
+	return noon.Format("MST")
+}
+
 func noCacheHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Cache-Control", "no-cache, must-revalidate")